@@ -0,0 +1,220 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StorageInfo holds the storage figures of a collection at a moment in time.
+// Sizes are in bytes.
+type StorageInfo struct {
+	TakenAt time.Time
+
+	Count       int64 `bson:"count"`
+	DataSize    int64 `bson:"size"`
+	IndexSize   int64 `bson:"totalIndexSize"`
+	StorageSize int64 `bson:"storageSize"`
+}
+
+// StorageSnapshot Method returns the current storage figures of configured collection, as per collStats.
+// Snapshots taken periodically could be persisted for growth trend analysis.
+func (m *Client) StorageSnapshot(ctx context.Context) (StorageInfo, error) {
+	defer m.logSlow("StorageSnapshot", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	var result StorageInfo
+
+	if errCommand := m.driver().
+		Database(m.Database).
+		RunCommand(
+			ctxLocal,
+			bson.D{{Key: "collStats", Value: m.Collection}},
+		).
+		Decode(&result); errCommand != nil {
+		return StorageInfo{},
+			errCommand
+	}
+
+	result.TakenAt = time.Now()
+
+	return result,
+		nil
+}
+
+// ReIndex Method rebuilds all indexes of configured collection.
+// The rebuild holds an exclusive lock on the database and is meant for maintenance windows, ex. after bulk loads,
+// not for routine use. Newer servers only allow it on standalone instances.
+func (m *Client) ReIndex(ctx context.Context) error {
+	defer m.logSlow("ReIndex", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	return m.driver().
+		Database(m.Database).
+		RunCommand(
+			ctxLocal,
+			bson.D{{Key: "reIndex", Value: m.Collection}},
+		).
+		Err()
+}
+
+// PlanCache Method returns the query plans the server cached for configured collection, as per $planCacheStats.
+// Helps diagnosing queries getting slow after data distribution changes. Cache is kept per server.
+func (m *Client) PlanCache(ctx context.Context) ([]bson.M, error) {
+	defer m.logSlow("PlanCache", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(
+			ctxLocal,
+			mongo.Pipeline{
+				{{Key: "$planCacheStats", Value: bson.M{}}},
+			},
+		)
+	if errAggregate != nil {
+		return nil,
+			errAggregate
+	}
+	defer cursor.Close(ctxLocal)
+
+	return m.walkMongoSet(ctxLocal, cursor)
+}
+
+// DropDatabase Method removes configured database with all its collections, ex. for test or tenant teardown.
+// Requires AllowDropDatabase set in configuration, preventing accidental calls.
+func (m *Client) DropDatabase(ctx context.Context) error {
+	defer m.logSlow("DropDatabase", time.Now())
+
+	if !m.AllowDropDatabase {
+		return ErrDropNotAllowed
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	return m.driver().
+		Database(m.Database).
+		Drop(ctxLocal)
+}
+
+// ConvertToCapped Method converts configured collection to a capped one of passed maximum size in bytes,
+// ex. for log retention, oldest records being removed once the size is reached.
+// The collection is rewritten under an exclusive lock and only the _id index is kept,
+// so this is a maintenance operation. Not supported on sharded collections.
+func (m *Client) ConvertToCapped(ctx context.Context, sizeBytes int64) error {
+	defer m.logSlow("ConvertToCapped", time.Now())
+
+	if sizeBytes < 1 {
+		return errors.New("capped collection size should be positive")
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	return m.driver().
+		Database(m.Database).
+		RunCommand(
+			ctxLocal,
+			bson.D{
+				{Key: "convertToCapped", Value: m.Collection},
+				{Key: "size", Value: sizeBytes},
+			},
+		).
+		Err()
+}
+
+// SetProfilingLevel Method sets the profiler of configured database, ex. during an incident.
+// Level 0 turns it off, 1 records operations slower than slowMs milliseconds and 2 records all operations.
+// Profiled operations are written to the system.profile collection of the database.
+func (m *Client) SetProfilingLevel(ctx context.Context, level int, slowMs int) error {
+	defer m.logSlow("SetProfilingLevel", time.Now())
+
+	if level < 0 || level > 2 {
+		return errors.Errorf("profiling level %d should be 0, 1 or 2", level)
+	}
+
+	if slowMs < 0 {
+		return errors.Errorf("slow threshold %d ms should not be negative", slowMs)
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	return m.driver().
+		Database(m.Database).
+		RunCommand(
+			ctxLocal,
+			bson.D{
+				{Key: "profile", Value: level},
+				{Key: "slowms", Value: slowMs},
+			},
+		).
+		Err()
+}
+
+// GetProfilingLevel Method returns the profiling level of configured database and its slow threshold in milliseconds.
+func (m *Client) GetProfilingLevel(ctx context.Context) (int, int, error) {
+	defer m.logSlow("GetProfilingLevel", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	var result struct {
+		Level  int `bson:"was"`
+		SlowMs int `bson:"slowms"`
+	}
+
+	if errCommand := m.driver().
+		Database(m.Database).
+		RunCommand(
+			ctxLocal,
+			bson.D{{Key: "profile", Value: -1}},
+		).
+		Decode(&result); errCommand != nil {
+		return 0,
+			0,
+			errCommand
+	}
+
+	return result.Level,
+		result.SlowMs,
+		nil
+}
+
+// RunCommand Method runs passed command on configured database and returns the server response,
+// ex. bson.D{{Key: "serverStatus", Value: 1}}. Command is a bson.D as the command name must be the first key.
+func (m *Client) RunCommand(ctx context.Context, command bson.D) (bson.M, error) {
+	defer m.logSlow("RunCommand", time.Now())
+
+	if len(command) == 0 {
+		return nil,
+			errors.New("command should not be empty")
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	var result bson.M
+
+	if errCommand := m.driver().
+		Database(m.Database).
+		RunCommand(ctxLocal, command).
+		Decode(&result); errCommand != nil {
+		return nil,
+			errors.Wrapf(errCommand, "could not run command %s", command[0].Key)
+	}
+
+	return result,
+		nil
+}
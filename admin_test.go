@@ -0,0 +1,124 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	mongoclient "mongoclient"
+	"mongoclient/testutil"
+)
+
+func TestStorageSnapshot(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+
+	info, errSnapshot := m.StorageSnapshot(ctx)
+	require.NoError(t, errSnapshot)
+	assert.EqualValues(t, 2, info.Count)
+	assert.Positive(t, info.DataSize)
+	assert.Positive(t, info.IndexSize)
+	assert.False(t, info.TakenAt.IsZero())
+}
+
+func TestReIndex(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	require.NoError(t, m.ReIndex(ctx))
+}
+
+func TestPlanCache(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, errPlans := m.PlanCache(ctx)
+	require.NoError(t, errPlans)
+}
+
+func TestDropDatabase(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	require.ErrorIs(t, m.DropDatabase(ctx), mongoclient.ErrDropNotAllowed)
+
+	m.AllowDropDatabase = true
+
+	require.NoError(t, m.DropDatabase(ctx))
+
+	count, errCount := m.Count(ctx, nil)
+	require.NoError(t, errCount)
+	assert.Zero(t, count)
+}
+
+func TestConvertToCapped(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	require.Error(t, m.ConvertToCapped(ctx, 0))
+	require.NoError(t, m.ConvertToCapped(ctx, 1<<20))
+
+	info, errSnapshot := m.StorageSnapshot(ctx)
+	require.NoError(t, errSnapshot)
+	assert.EqualValues(t, 1, info.Count)
+}
+
+func TestProfilingLevel(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.Error(t, m.SetProfilingLevel(ctx, 3, 100))
+	require.Error(t, m.SetProfilingLevel(ctx, 1, -1))
+
+	require.NoError(t, m.SetProfilingLevel(ctx, 1, 50))
+
+	level, slowMs, errGet := m.GetProfilingLevel(ctx)
+	require.NoError(t, errGet)
+	assert.Equal(t, 1, level)
+	assert.Equal(t, 50, slowMs)
+
+	require.NoError(t, m.SetProfilingLevel(ctx, 0, 100))
+}
+
+func TestRunCommand(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, errEmpty := m.RunCommand(ctx, bson.D{})
+	require.Error(t, errEmpty)
+
+	testInsertOne(ctx, t, m, john)
+
+	stats, errStats := m.RunCommand(ctx, bson.D{{Key: "collStats", Value: "persons"}, {Key: "scale", Value: 1}})
+	require.NoError(t, errStats)
+	assert.EqualValues(t, 1, stats["count"])
+
+	_, errUnknown := m.RunCommand(ctx, bson.D{{Key: "noSuchCommand", Value: 1}})
+	require.Error(t, errUnknown)
+}
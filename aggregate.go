@@ -0,0 +1,281 @@
+package mongoclient
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Aggregate Method runs passed pipeline, ex. $match, $group, $lookup or $project stages, on configured collection.
+func (m *Client) Aggregate(ctx context.Context, pipeline []bson.D, opts ...AggregateOption) ([]bson.M, error) {
+	defer m.logSlow("Aggregate", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(ctxLocal, pipeline, newAggregateOptions(opts...))
+	if errAggregate != nil {
+		return nil,
+			errAggregate
+	}
+	defer cursor.Close(ctxLocal)
+
+	return m.walkMongoSet(ctxLocal, cursor)
+}
+
+// FindPageFacet Method returns one page of the records matching passed filter together with the total number
+// of matching records, in one round trip using a $facet stage.
+// Sort should be set for pages to be stable. The page is returned in one document so it must fit in 16MB.
+func (m *Client) FindPageFacet(ctx context.Context, filter []byte, skip, limit int64, sort bson.D) ([]bson.M, int64, error) {
+	defer m.logSlow("FindPageFacet", time.Now())
+
+	if skip < 0 || limit < 1 {
+		return nil,
+			0,
+			errors.Errorf("skip %d should not be negative and limit %d should be positive", skip, limit)
+	}
+
+	bsonFilter, errConv := m.filterFromJSONOrAll(filter)
+	if errConv != nil {
+		return nil,
+			0,
+			errConv
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	page := bson.A{}
+
+	if len(sort) > 0 {
+		page = append(page, bson.D{{Key: "$sort", Value: sort}})
+	}
+
+	page = append(page,
+		bson.D{{Key: "$skip", Value: skip}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(
+			ctxLocal,
+			mongo.Pipeline{
+				{{Key: "$match", Value: bsonFilter}},
+				{{Key: "$facet", Value: bson.M{
+					"data":  page,
+					"count": bson.A{bson.D{{Key: "$count", Value: "total"}}},
+				}}},
+			},
+		)
+	if errAggregate != nil {
+		return nil,
+			0,
+			errAggregate
+	}
+	defer cursor.Close(ctxLocal)
+
+	var facets []struct {
+		Data  []bson.M `bson:"data"`
+		Count []struct {
+			Total int64 `bson:"total"`
+		} `bson:"count"`
+	}
+
+	if errDecode := cursor.All(ctxLocal, &facets); errDecode != nil {
+		return nil,
+			0,
+			errors.Wrap(errDecode, "could not decode page")
+	}
+
+	var (
+		result []bson.M
+		total  int64
+	)
+
+	if m.EmptyResultNonNil {
+		result = []bson.M{}
+	}
+
+	if len(facets) > 0 {
+		result = append(result, facets[0].Data...)
+
+		if len(facets[0].Count) > 0 {
+			total = facets[0].Count[0].Total
+		}
+	}
+
+	return result,
+		total,
+		nil
+}
+
+// FindOrphans Method returns the IDs of the records whose refField does not match the targetField
+// of any record in targetColl, collection of the same database.
+// Records without refField are not considered orphans.
+// Errors when an orphan has an _id that is not an object ID, ex. from an IDGenerator.
+func (m *Client) FindOrphans(ctx context.Context, refField, targetColl, targetField string) ([]primitive.ObjectID, error) {
+	defer m.logSlow("FindOrphans", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	const joined = "_joined"
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(
+			ctxLocal,
+			mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{refField: bson.M{"$exists": true, "$ne": nil}}}},
+				{{Key: "$lookup", Value: bson.M{
+					"from":         targetColl,
+					"localField":   refField,
+					"foreignField": targetField,
+					"as":           joined,
+				}}},
+				{{Key: "$match", Value: bson.M{joined: bson.M{"$size": 0}}}},
+				{{Key: "$project", Value: bson.M{"_id": 1}}},
+			},
+		)
+	if errAggregate != nil {
+		return nil,
+			errAggregate
+	}
+	defer cursor.Close(ctxLocal)
+
+	records, errWalk := m.walkMongoSet(ctxLocal, cursor)
+	if errWalk != nil {
+		return nil,
+			errWalk
+	}
+
+	return objectIDsStrict(records)
+}
+
+// AggregateWindow Method computes window functions, ex. ranks or running totals, over the records of configured
+// collection using a $setWindowFields stage.
+// Records are partitioned by passed field, with an empty field meaning one partition, and ordered within it by sortBy.
+// Output holds the computed fields, ex. bson.M{"rank": bson.M{"$rank": bson.M{}}}.
+// Requires Mongo DB 5.0 or later.
+func (m *Client) AggregateWindow(ctx context.Context, partitionBy string, sortBy bson.D, output bson.M) ([]bson.M, error) {
+	defer m.logSlow("AggregateWindow", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	stage := bson.D{}
+
+	if partitionBy != "" {
+		if !strings.HasPrefix(partitionBy, "$") {
+			partitionBy = "$" + partitionBy
+		}
+
+		stage = append(stage, bson.E{Key: "partitionBy", Value: partitionBy})
+	}
+
+	if len(sortBy) > 0 {
+		stage = append(stage, bson.E{Key: "sortBy", Value: sortBy})
+	}
+
+	stage = append(stage, bson.E{Key: "output", Value: output})
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(
+			ctxLocal,
+			mongo.Pipeline{
+				{{Key: "$setWindowFields", Value: stage}},
+			},
+		)
+	if errAggregate != nil {
+		return nil,
+			errAggregate
+	}
+	defer cursor.Close(ctxLocal)
+
+	return m.walkMongoSet(ctxLocal, cursor)
+}
+
+// CountByTimeBucket Method counts the records matching passed filter per time bucket of timeField.
+// Granularity is one of "hour", "day" or "month", buckets are keyed by their UTC start.
+// An empty filter counts all records.
+// Records where timeField is not a date are ignored. Requires Mongo DB 5.0 or later.
+func (m *Client) CountByTimeBucket(ctx context.Context, timeField string, granularity string, filter []byte) (map[time.Time]int64, error) {
+	defer m.logSlow("CountByTimeBucket", time.Now())
+
+	switch granularity {
+	case "hour", "day", "month":
+	default:
+		return nil,
+			errors.Errorf("granularity %q should be hour, day or month", granularity)
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonFilter, errConv := m.filterFromJSONOrAll(filter)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(
+			ctxLocal,
+			mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{
+					"$and": bson.A{
+						bsonFilter,
+						bson.M{timeField: bson.M{"$type": "date"}},
+					},
+				}}},
+				{{Key: "$group", Value: bson.M{
+					"_id": bson.M{
+						"$dateTrunc": bson.M{
+							"date": "$" + timeField,
+							"unit": granularity,
+						},
+					},
+					"count": bson.M{"$sum": 1},
+				}}},
+			},
+		)
+	if errAggregate != nil {
+		return nil,
+			errAggregate
+	}
+	defer cursor.Close(ctxLocal)
+
+	var buckets []struct {
+		Start time.Time `bson:"_id"`
+		Count int64     `bson:"count"`
+	}
+
+	if errDecode := cursor.All(ctxLocal, &buckets); errDecode != nil {
+		return nil,
+			errors.Wrap(errDecode, "could not decode buckets")
+	}
+
+	result := make(map[time.Time]int64, len(buckets))
+
+	for _, bucket := range buckets {
+		result[bucket.Start.UTC()] = bucket.Count
+	}
+
+	return result,
+		nil
+}
@@ -0,0 +1,234 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	mongoclient "mongoclient"
+	"mongoclient/testutil"
+)
+
+func TestAggregate(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+	testInsertOne(ctx, t, m, record{Name: "eve", Gender: "female", Age: 30})
+
+	records, errAggregate := m.Aggregate(
+		ctx,
+		[]bson.D{
+			{{Key: "$group", Value: bson.M{"_id": "$Gender", "count": bson.M{"$sum": 1}}}},
+			{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		},
+		mongoclient.WithAllowDiskUse(),
+	)
+	require.NoError(t, errAggregate)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "female", records[0]["_id"])
+	assert.EqualValues(t, 2, records[0]["count"])
+	assert.Equal(t, "male", records[1]["_id"])
+	assert.EqualValues(t, 1, records[1]["count"])
+}
+
+func TestFindOrphans(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	orders := m.UseCollection("orders")
+
+	_, errInsert := orders.InsertOne(ctx, []byte(`{"Customer":"john"}`))
+	require.NoError(t, errInsert)
+
+	idOrphan, errInsert := orders.InsertOne(ctx, []byte(`{"Customer":"ghost"}`))
+	require.NoError(t, errInsert)
+
+	orphans, errOrphans := orders.FindOrphans(ctx, "Customer", "persons", "Name")
+	require.NoError(t, errOrphans)
+	assert.Equal(t, []primitive.ObjectID{idOrphan}, orphans)
+}
+
+func TestAggregateWindow(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, record{Name: "ann", Gender: "female", Age: 30})
+	testInsertOne(ctx, t, m, record{Name: "eve", Gender: "female", Age: 40})
+	testInsertOne(ctx, t, m, record{Name: "bob", Gender: "male", Age: 50})
+
+	records, errAggregate := m.AggregateWindow(
+		ctx,
+		"Gender",
+		bson.D{{Key: "Age", Value: -1}},
+		bson.M{"rank": bson.M{"$rank": bson.M{}}},
+	)
+	require.NoError(t, errAggregate)
+	require.Len(t, records, 3)
+
+	ranks := make(map[string]any, len(records))
+
+	for _, record := range records {
+		ranks[record["Name"].(string)] = record["rank"]
+	}
+
+	assert.EqualValues(t, 1, ranks["eve"])
+	assert.EqualValues(t, 2, ranks["ann"])
+	assert.EqualValues(t, 1, ranks["bob"])
+}
+
+func TestAggregateForEach(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+	testInsertOne(ctx, t, m, mary)
+
+	counts := make(map[string]int32)
+
+	errAggregate := m.AggregateForEach(
+		ctx,
+		mongo.Pipeline{
+			{{Key: "$group", Value: bson.M{"_id": "$Name", "count": bson.M{"$sum": 1}}}},
+		},
+		func(group bson.M) error {
+			counts[group["_id"].(string)] = group["count"].(int32)
+
+			return nil
+		},
+	)
+	require.NoError(t, errAggregate)
+	assert.Equal(t, map[string]int32{"john": 1, "mary": 2}, counts)
+}
+
+func TestCountByTimeBucket(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, created := range []string{
+		"2024-03-01T10:15:00Z",
+		"2024-03-01T10:45:00Z",
+		"2024-03-01T11:05:00Z",
+	} {
+		_, errInsert := m.InsertOne(ctx, []byte(`{"Name":"mary"}`))
+		require.NoError(t, errInsert)
+
+		at, errParse := time.Parse(time.RFC3339, created)
+		require.NoError(t, errParse)
+
+		_, errUpdate := m.UpdateOne(ctx, bson.M{"Created": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"Created": at}})
+		require.NoError(t, errUpdate)
+	}
+
+	counts, errCount := m.CountByTimeBucket(ctx, "Created", "hour", []byte(`{"Name":"mary"}`))
+	require.NoError(t, errCount)
+	assert.Equal(t,
+		map[time.Time]int64{
+			time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC): 2,
+			time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC): 1,
+		},
+		counts,
+	)
+
+	countsAll, errAll := m.CountByTimeBucket(ctx, "Created", "day", nil)
+	require.NoError(t, errAll)
+	assert.Equal(t,
+		map[time.Time]int64{
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC): 3,
+		},
+		countsAll,
+	)
+
+	_, errGranularity := m.CountByTimeBucket(ctx, "Created", "week", nil)
+	require.Error(t, errGranularity)
+}
+
+func TestBucket(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, age := range []uint{5, 15, 18, 25, 70} {
+		testInsertOne(ctx, t, m, record{Name: "ann", Gender: "female", Age: age})
+	}
+
+	buckets, errBucket := m.Bucket(ctx, "Age", []float64{0, 10, 20, 30}, nil)
+	require.NoError(t, errBucket)
+	assert.Equal(t,
+		map[string]int64{
+			"0":                     1,
+			"10":                    2,
+			"20":                    1,
+			mongoclient.BucketOther: 1,
+		},
+		buckets,
+	)
+
+	bucketsAuto, errAuto := m.BucketAuto(ctx, "Age", 2)
+	require.NoError(t, errAuto)
+	require.Len(t, bucketsAuto, 2)
+
+	var total int64
+
+	for _, count := range bucketsAuto {
+		total += count
+	}
+
+	assert.EqualValues(t, 5, total)
+}
+
+func TestFindPageFacet(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for age := range uint(5) {
+		testInsertOne(ctx, t, m, record{Name: "ann", Gender: "female", Age: age})
+	}
+
+	testInsertOne(ctx, t, m, john)
+
+	page, total, errPage := m.FindPageFacet(
+		ctx,
+		[]byte(`{"Name":"ann"}`),
+		1,
+		2,
+		bson.D{{Key: "Age", Value: 1}},
+	)
+	require.NoError(t, errPage)
+	assert.EqualValues(t, 5, total)
+	require.Len(t, page, 2)
+	assert.EqualValues(t, 1, page[0]["Age"])
+	assert.EqualValues(t, 2, page[1]["Age"])
+
+	empty, totalEmpty, errEmpty := m.FindPageFacet(ctx, []byte(`{"Name":"nobody"}`), 0, 10, nil)
+	require.NoError(t, errEmpty)
+	assert.Zero(t, totalEmpty)
+	assert.Empty(t, empty)
+
+	_, _, errLimit := m.FindPageFacet(ctx, nil, 0, 0, nil)
+	require.Error(t, errLimit)
+}
@@ -0,0 +1,136 @@
+package mongoclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BucketOther is the key counting the records with field values outside of the Bucket boundaries.
+const BucketOther = "other"
+
+// Bucket Method counts the records matching passed filter per range of field values, as per $bucket.
+// Boundaries are the ascending lower bounds of the ranges, the last one being the exclusive upper bound
+// of the last range. Counts are keyed by the lower bound of their range, ex. "10",
+// values not within the boundaries are counted under BucketOther.
+func (m *Client) Bucket(ctx context.Context, field string, boundaries []float64, filter []byte) (map[string]int64, error) {
+	defer m.logSlow("Bucket", time.Now())
+
+	if errBoundaries := validateBoundaries(boundaries); errBoundaries != nil {
+		return nil,
+			errBoundaries
+	}
+
+	bsonFilter, errConv := m.filterFromJSONOrAll(filter)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	return m.countBuckets(
+		ctx,
+		mongo.Pipeline{
+			{{Key: "$match", Value: bsonFilter}},
+			{{Key: "$bucket", Value: bson.M{
+				"groupBy":    "$" + field,
+				"boundaries": boundaries,
+				"default":    BucketOther,
+				"output":     bson.M{"count": bson.M{"$sum": 1}},
+			}}},
+		},
+		func(id any) any {
+			return id
+		},
+	)
+}
+
+// BucketAuto Method splits the records in passed number of buckets of field values with evenly distributed counts,
+// as per $bucketAuto. Counts are keyed by the lower bound of their bucket.
+// Fewer buckets are returned when there are not enough distinct values.
+func (m *Client) BucketAuto(ctx context.Context, field string, count int) (map[string]int64, error) {
+	defer m.logSlow("BucketAuto", time.Now())
+
+	if count < 1 {
+		return nil,
+			errors.New("number of buckets should be positive")
+	}
+
+	return m.countBuckets(
+		ctx,
+		mongo.Pipeline{
+			{{Key: "$bucketAuto", Value: bson.M{
+				"groupBy": "$" + field,
+				"buckets": count,
+				"output":  bson.M{"count": bson.M{"$sum": 1}},
+			}}},
+		},
+		func(id any) any {
+			bounds, isDocument := id.(bson.D)
+			if !isDocument {
+				return id
+			}
+
+			for _, bound := range bounds {
+				if bound.Key == "min" {
+					return bound.Value
+				}
+			}
+
+			return id
+		},
+	)
+}
+
+// countBuckets runs passed bucketing pipeline and keys the counts by the bound extracted from each bucket ID.
+func (m *Client) countBuckets(ctx context.Context, pipeline mongo.Pipeline, bound func(id any) any) (map[string]int64, error) {
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(ctxLocal, pipeline)
+	if errAggregate != nil {
+		return nil,
+			errAggregate
+	}
+	defer cursor.Close(ctxLocal)
+
+	var buckets []struct {
+		ID    any   `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+
+	if errDecode := cursor.All(ctxLocal, &buckets); errDecode != nil {
+		return nil,
+			errors.Wrap(errDecode, "could not decode buckets")
+	}
+
+	result := make(map[string]int64, len(buckets))
+
+	for _, bucket := range buckets {
+		result[fmt.Sprint(bound(bucket.ID))] = bucket.Count
+	}
+
+	return result,
+		nil
+}
+
+// validateBoundaries checks passed bucket boundaries are at least two and strictly ascending.
+func validateBoundaries(boundaries []float64) error {
+	if len(boundaries) < 2 {
+		return errors.Errorf("bucket boundaries should be at least 2, are %d", len(boundaries))
+	}
+
+	for i := 1; i < len(boundaries); i++ {
+		if boundaries[i] <= boundaries[i-1] {
+			return errors.Errorf("bucket boundaries should be ascending, %v follows %v", boundaries[i], boundaries[i-1])
+		}
+	}
+
+	return nil
+}
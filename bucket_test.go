@@ -0,0 +1,15 @@
+package mongoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBoundaries(t *testing.T) {
+	require.NoError(t, validateBoundaries([]float64{0, 10, 20}))
+
+	require.Error(t, validateBoundaries([]float64{10}), "too few")
+	require.Error(t, validateBoundaries([]float64{0, 20, 10}), "descending")
+	require.Error(t, validateBoundaries([]float64{0, 10, 10}), "duplicate")
+}
@@ -0,0 +1,29 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BulkWrite Method sends passed mixed inserts, updates and deletes to configured collection in one batch.
+// When ordered the operations run in passed order and stop at the first failure,
+// otherwise the server may run them in any order and continues past failures.
+func (m *Client) BulkWrite(ctx context.Context, models []mongo.WriteModel, ordered bool) (*mongo.BulkWriteResult, error) {
+	defer m.logSlow("BulkWrite", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	result, errWrite := m.writeCollection().
+		BulkWrite(
+			ctxLocal,
+			models,
+			options.BulkWrite().SetOrdered(ordered),
+		)
+
+	return result,
+		writeConcernTimeout(errWrite)
+}
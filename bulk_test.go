@@ -0,0 +1,43 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"mongoclient/testutil"
+)
+
+func TestBulkWrite(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	idJohn := testInsertOne(ctx, t, m, john)
+	idMary := testInsertOne(ctx, t, m, mary)
+
+	result, errWrite := m.BulkWrite(
+		ctx,
+		[]mongo.WriteModel{
+			mongo.NewInsertOneModel().SetDocument(bson.M{"Name": "eve"}),
+			mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": idJohn}).
+				SetUpdate(bson.M{"$set": bson.M{"Age": 45}}),
+			mongo.NewDeleteOneModel().SetFilter(bson.M{"_id": idMary}),
+		},
+		true,
+	)
+	require.NoError(t, errWrite)
+	assert.EqualValues(t, 1, result.InsertedCount)
+	assert.EqualValues(t, 1, result.ModifiedCount)
+	assert.EqualValues(t, 1, result.DeletedCount)
+
+	count, errCount := m.Count(ctx, nil)
+	require.NoError(t, errCount)
+	assert.EqualValues(t, 2, count)
+}
@@ -0,0 +1,138 @@
+package mongoclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Change streams require a replica set or a sharded cluster.
+
+// WatchOption configures the change stream opened by Watch.
+type WatchOption func(*options.ChangeStreamOptions)
+
+// WithResumeAfter Option resumes the change stream after the event with passed resume token,
+// as returned by a previous Watch call.
+func WithResumeAfter(token bson.Raw) WatchOption {
+	return func(o *options.ChangeStreamOptions) {
+		o.SetResumeAfter(token)
+	}
+}
+
+// WithFullDocumentBeforeChange Option adds to update, replace and delete events the record as it was before
+// the change, under fullDocumentBeforeChange. Events without an available pre-image carry none.
+// Requires Mongo DB 6.0 or later and the collection created or modified with changeStreamPreAndPostImages enabled.
+func WithFullDocumentBeforeChange() WatchOption {
+	return func(o *options.ChangeStreamOptions) {
+		o.SetFullDocumentBeforeChange(options.WhenAvailable)
+	}
+}
+
+// Watch Method subscribes to the changes of configured collection and invokes fn for each change event.
+// Pipeline filters or reshapes the events and could be nil.
+// Watch blocks until passed context is done, fn returns an error or the stream fails.
+// The stream is always closed on return and the resume token of the last processed event is returned
+// so the caller could restart with WithResumeAfter.
+// On context cancellation the context error is returned.
+func (m *Client) Watch(ctx context.Context, pipeline []bson.D, fn func(bson.M) error, opts ...WatchOption) (bson.Raw, error) {
+	streamOptions := options.ChangeStream()
+
+	for _, opt := range opts {
+		opt(streamOptions)
+	}
+
+	if pipeline == nil {
+		pipeline = []bson.D{}
+	}
+
+	ctxOpen, cancelOpen := m.withDeadline(ctx)
+	defer cancelOpen()
+
+	stream, errWatch := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Watch(ctxOpen, pipeline, streamOptions)
+	if errWatch != nil {
+		return nil,
+			errWatch
+	}
+
+	defer func() {
+		// passed context could be already done, closing needs its own.
+		ctxClose, cancelClose := m.withDeadline(context.Background())
+		defer cancelClose()
+
+		_ = stream.Close(ctxClose)
+	}()
+
+	var lastToken bson.Raw
+
+	for stream.Next(ctx) {
+		var event bson.M
+
+		if errDecode := stream.Decode(&event); errDecode != nil {
+			return lastToken,
+				errors.Wrap(errDecode, "could not decode change event")
+		}
+
+		if errFn := fn(event); errFn != nil {
+			return lastToken,
+				errFn
+		}
+
+		lastToken = stream.ResumeToken()
+	}
+
+	if errCtx := ctx.Err(); errCtx != nil {
+		return lastToken,
+			errCtx
+	}
+
+	return lastToken,
+		errors.Wrap(stream.Err(), "change stream error")
+}
+
+// WatchOps Method streams the change events of configured collection having one of passed operation types,
+// ex. "insert" or "update", filtering server side. With no operation types all events are streamed.
+// Both channels are closed when the stream ends, with at most one error sent before.
+// No error is sent when the stream ends because passed context is done.
+func (m *Client) WatchOps(ctx context.Context, opTypes []string, opts ...WatchOption) (<-chan bson.M, <-chan error) {
+	var pipeline []bson.D
+
+	if len(opTypes) > 0 {
+		pipeline = []bson.D{
+			{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": opTypes}}}},
+		}
+	}
+
+	events := make(chan bson.M)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		_, errWatch := m.Watch(
+			ctx,
+			pipeline,
+			func(event bson.M) error {
+				select {
+				case events <- event:
+					return nil
+
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+			opts...,
+		)
+		if errWatch != nil && ctx.Err() == nil {
+			errs <- errWatch
+		}
+	}()
+
+	return events,
+		errs
+}
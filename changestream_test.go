@@ -0,0 +1,80 @@
+package mongoclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"mongoclient/testutil"
+)
+
+func TestWatch(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t, mongodb.WithReplicaSet("rs0"))
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	go func() {
+		time.Sleep(time.Second)
+
+		_, _ = m.InsertOne(ctx, []byte(`{"Name":"mary"}`))
+		_, _ = m.InsertOne(ctx, []byte(`{"Name":"john"}`))
+	}()
+
+	var events []bson.M
+
+	token, errWatch := m.Watch(
+		ctx,
+		nil,
+		func(event bson.M) error {
+			events = append(events, event)
+
+			if len(events) == 2 {
+				cancel()
+			}
+
+			return nil
+		},
+	)
+	require.True(t, errors.Is(errWatch, context.Canceled))
+	assert.Len(t, events, 2)
+	assert.NotEmpty(t, token)
+}
+
+func TestWatchOps(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t, mongodb.WithReplicaSet("rs0"))
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events, errs := m.WatchOps(ctx, []string{"insert"})
+
+	go func() {
+		time.Sleep(time.Second)
+
+		_, _ = m.InsertOne(ctx, []byte(`{"Name":"mary"}`))
+		_, _ = m.DeleteOne(ctx, []byte(`{"Name":"mary"}`))
+		_, _ = m.InsertOne(ctx, []byte(`{"Name":"john"}`))
+	}()
+
+	for range 2 {
+		event := <-events
+		require.NotNil(t, event)
+		assert.Equal(t, "insert", event["operationType"])
+	}
+
+	cancel()
+
+	for range events {
+	}
+
+	assert.NoError(t, <-errs)
+}
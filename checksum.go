@@ -0,0 +1,46 @@
+package mongoclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CollectionChecksum Method returns a hex SHA-256 digest of the records matching passed filter,
+// an empty filter covering all records, ex. for confirming source and target match after a migration.
+// Records are hashed in _id order in canonical form so field order and number types do not change the digest.
+func (m *Client) CollectionChecksum(ctx context.Context, filter []byte) (string, error) {
+	bsonFilter, errConv := m.filterFromJSONOrAll(filter)
+	if errConv != nil {
+		return "", errConv
+	}
+
+	digest := sha256.New()
+
+	errWalk := m.AggregateForEach(
+		ctx,
+		mongo.Pipeline{
+			{{Key: "$match", Value: bsonFilter}},
+			{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		},
+		func(record bson.M) error {
+			canonical, errCanonical := canonicalBSON(record)
+			if errCanonical != nil {
+				return errCanonical
+			}
+
+			_, errWrite := digest.Write(canonical)
+
+			return errWrite
+		},
+	)
+	if errWalk != nil {
+		return "", errWalk
+	}
+
+	return hex.EncodeToString(digest.Sum(nil)),
+		nil
+}
@@ -0,0 +1,43 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"mongoclient/testutil"
+)
+
+func TestCollectionChecksum(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	source := m.UseCollection("source")
+	target := m.UseCollection("target")
+
+	_, errSource := source.InsertOne(ctx, []byte(`{"_id":1,"Name":"john","Age":44}`))
+	require.NoError(t, errSource)
+
+	_, errTarget := target.InsertOne(ctx, []byte(`{"Age":44,"Name":"john","_id":1}`))
+	require.NoError(t, errTarget)
+
+	checksumSource, errChecksumSource := source.CollectionChecksum(ctx, nil)
+	require.NoError(t, errChecksumSource)
+	assert.Len(t, checksumSource, 64)
+
+	checksumTarget, errChecksumTarget := target.CollectionChecksum(ctx, nil)
+	require.NoError(t, errChecksumTarget)
+	assert.Equal(t, checksumSource, checksumTarget)
+
+	_, errUpdate := target.UpdateOne(ctx, bson.M{"_id": 1.0}, bson.M{"$set": bson.M{"Age": 45}})
+	require.NoError(t, errUpdate)
+
+	checksumChanged, errChecksumChanged := target.CollectionChecksum(ctx, nil)
+	require.NoError(t, errChecksumChanged)
+	assert.NotEqual(t, checksumSource, checksumChanged)
+}
@@ -0,0 +1,192 @@
+package mongoclient
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"golang.org/x/sync/errgroup"
+)
+
+// Ping Method checks the primary is reachable, ex. for a liveness probe.
+func (m *Client) Ping(ctx context.Context) error {
+	return m.PingReadPref(ctx, readpref.Primary())
+}
+
+// PingReadPref Method checks a server selected with passed read preference is reachable,
+// ex. readpref.Nearest() for probes tolerating a missing primary.
+func (m *Client) PingReadPref(ctx context.Context, rp *readpref.ReadPref) error {
+	defer m.logSlow("Ping", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	return m.driver().Ping(ctxLocal, rp)
+}
+
+// Warmup Method pre-populates the connection pool by issuing passed number of concurrent pings, best effort.
+// Pings could reuse a connection another ping just returned and the driver limits the connections established
+// at once, so the pool could hold fewer than n connections afterwards. Use Cfg.MinPoolSize to guarantee them.
+func (m *Client) Warmup(ctx context.Context, n int) error {
+	defer m.logSlow("Warmup", time.Now())
+
+	if n < 1 {
+		return errors.New("number of connections to warm up should be positive")
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	group, ctxGroup := errgroup.WithContext(ctxLocal)
+
+	for range n {
+		group.Go(func() error {
+			return m.driver().Ping(ctxGroup, readpref.Primary())
+		})
+	}
+
+	return errors.Wrap(group.Wait(), "could not warm up connection pool")
+}
+
+// connection holds the driver client shared by a client and the clients derived from it,
+// ex. with UseCollection or UseDatabase.
+type connection struct {
+	current       atomic.Pointer[mongo.Client]
+	clientOptions *options.ClientOptions
+	pool          *poolCounters
+
+	// disconnected is set when the current driver client got disconnected.
+	disconnected atomic.Bool
+	reconnecting sync.Mutex
+
+	// closed is set by Close, after which the driver client is not replaced anymore.
+	closed atomic.Bool
+
+	// slots holds one value per running operation, nil when their number is not limited.
+	slots    chan struct{}
+	inFlight atomic.Int64
+}
+
+func newConnection(config *Cfg) *connection {
+	result := connection{
+		pool: &poolCounters{},
+	}
+
+	if config.MaxConcurrentOps > 0 {
+		result.slots = make(chan struct{}, config.MaxConcurrentOps)
+	}
+
+	result.clientOptions = options.Client().
+		ApplyURI(config.URL).
+		SetPoolMonitor(result.pool.monitor()).
+		SetServerMonitor(&event.ServerMonitor{
+			TopologyClosed: func(*event.TopologyClosedEvent) {
+				result.disconnected.Store(true)
+			},
+		})
+
+	if config.WriteConcern != nil {
+		result.clientOptions.SetWriteConcern(config.WriteConcern.toDriver())
+	}
+
+	if config.MaxPoolSize > 0 {
+		result.clientOptions.SetMaxPoolSize(config.MaxPoolSize)
+	}
+
+	if config.MinPoolSize > 0 {
+		result.clientOptions.SetMinPoolSize(config.MinPoolSize)
+	}
+
+	if config.MaxConnIdleTime > 0 {
+		result.clientOptions.SetMaxConnIdleTime(config.MaxConnIdleTime)
+	}
+
+	if config.SocketTimeout > 0 {
+		result.clientOptions.SetSocketTimeout(config.SocketTimeout)
+	}
+
+	if config.ServerAPIVersion != "" {
+		result.clientOptions.SetServerAPIOptions(
+			options.ServerAPI(options.ServerAPIVersion(config.ServerAPIVersion)).
+				SetStrict(config.ServerAPIStrict).
+				SetDeprecationErrors(config.ServerAPIDeprecationErrors),
+		)
+	}
+
+	if config.Username != "" || (config.AuthSource != "" && result.clientOptions.Auth != nil) {
+		result.clientOptions.SetAuth(credential(result.clientOptions.Auth, config))
+	}
+
+	return &result
+}
+
+// credential returns the URL credential, if any, with the fields set in passed configuration replacing its own.
+func credential(fromURL *options.Credential, config *Cfg) options.Credential {
+	var result options.Credential
+
+	if fromURL != nil {
+		result = *fromURL
+	}
+
+	if config.Username != "" {
+		result.Username = config.Username
+		result.Password = config.Password
+		result.PasswordSet = config.Password != ""
+	}
+
+	if config.AuthSource != "" {
+		result.AuthSource = config.AuthSource
+	}
+
+	return result
+}
+
+// Raw Method returns the driver client, for capabilities the package does not cover.
+// Operations run on it bypass the package handling, ie. execution timeouts, slow operation logging,
+// denied filter operators and configured write concern overrides.
+// With AutoReconnect the driver client could be replaced, so it should be fetched for each use and not kept.
+func (m *Client) Raw() *mongo.Client {
+	return m.driver()
+}
+
+// driver returns the driver client operations should use.
+// With AutoReconnect a disconnected driver client is first replaced by a newly connected one.
+func (m *Client) driver() *mongo.Client {
+	if m.AutoReconnect && m.conn.disconnected.Load() && !m.conn.closed.Load() {
+		m.reconnect()
+	}
+
+	return m.conn.current.Load()
+}
+
+// reconnect replaces the disconnected driver client, unless a concurrent call already did.
+// On failure the disconnected client is kept so the operation fails as it would have without reconnecting.
+func (m *Client) reconnect() {
+	m.conn.reconnecting.Lock()
+	defer m.conn.reconnecting.Unlock()
+
+	if !m.conn.disconnected.Load() || m.conn.closed.Load() {
+		return
+	}
+
+	ctx, cancel := m.withDeadline(context.Background())
+	defer cancel()
+
+	fresh, errConnect := mongo.Connect(ctx, m.conn.clientOptions)
+	if errConnect != nil {
+		m.logger().Warnf("could not reconnect to %s: %s", m.Database, errConnect)
+
+		return
+	}
+
+	m.conn.current.Store(fresh)
+	m.conn.disconnected.Store(false)
+
+	m.logger().Infof("reconnected to %s", m.Database)
+}
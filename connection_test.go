@@ -0,0 +1,57 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	"mongoclient/testutil"
+)
+
+func TestPing(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, m.Ping(ctx))
+	require.NoError(t, m.PingReadPref(ctx, readpref.Nearest()))
+
+	require.NoError(t, m.Disconnect(ctx))
+	require.Error(t, m.Ping(ctx))
+}
+
+func TestRaw(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, m.Raw().Ping(ctx, readpref.Primary()))
+}
+
+func TestWarmup(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, m.Warmup(ctx, 5))
+	require.Error(t, m.Warmup(ctx, 0))
+}
+
+func TestPoolStats(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, m.Warmup(ctx, 3))
+
+	stats := m.PoolStats()
+	require.Zero(t, stats.CheckedOut)
+	require.Zero(t, stats.WaitQueue)
+	require.Positive(t, stats.Available)
+}
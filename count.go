@@ -0,0 +1,39 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+)
+
+// Count Method returns the number of records matching passed filter, an empty or nil filter counts all records.
+func (m *Client) Count(ctx context.Context, filterJSON []byte) (int64, error) {
+	defer m.logSlow("Count", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonFilter, errConv := m.filterFromJSONOrAll(filterJSON)
+	if errConv != nil {
+		return 0,
+			errConv
+	}
+
+	return m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		CountDocuments(ctxLocal, bsonFilter)
+}
+
+// EstimatedCount Method returns the number of records in the collection based on its metadata.
+// It does not scan the records so it is cheap but can be off, ex. after an unclean shutdown or on sharded clusters.
+func (m *Client) EstimatedCount(ctx context.Context) (int64, error) {
+	defer m.logSlow("EstimatedCount", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	return m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		EstimatedDocumentCount(ctxLocal)
+}
@@ -0,0 +1,54 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"mongoclient/testutil"
+)
+
+func TestCount(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	const noRecords = 5
+
+	for range noRecords {
+		testInsertOne(ctx, t, m, john)
+	}
+
+	testInsertOne(ctx, t, m, mary)
+
+	countAll, errAll := m.Count(ctx, nil)
+	require.NoError(t, errAll)
+	require.EqualValues(t, noRecords+1, countAll)
+
+	countEmpty, errEmpty := m.Count(ctx, []byte{})
+	require.NoError(t, errEmpty)
+	require.EqualValues(t, noRecords+1, countEmpty)
+
+	countJohn, errJohn := m.Count(ctx, []byte(`{"Name":"john"}`))
+	require.NoError(t, errJohn)
+	require.EqualValues(t, noRecords, countJohn)
+}
+
+func TestEstimatedCount(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	const noRecords = 3
+
+	for range noRecords {
+		testInsertOne(ctx, t, m, mary)
+	}
+
+	count, errCount := m.EstimatedCount(ctx)
+	require.NoError(t, errCount)
+	require.EqualValues(t, noRecords, count)
+}
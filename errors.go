@@ -0,0 +1,133 @@
+package mongoclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrDocumentTooLarge is returned when a record exceeds the Mongo DB document size limit.
+var ErrDocumentTooLarge = errors.New("document exceeds 16MB size limit")
+
+// ErrForbiddenOperator is returned when a JSON filter uses an operator denied by configuration.
+var ErrForbiddenOperator = errors.New("filter uses a forbidden operator")
+
+// ErrNotFound is returned when no record matches the filter.
+// The driver error is kept wrapped underneath.
+var ErrNotFound = errors.New("no record found")
+
+// ErrDropNotAllowed is returned by DropDatabase when configuration does not allow it.
+var ErrDropNotAllowed = errors.New("dropping the database is not allowed by configuration")
+
+// duplicateKeyCodes are the server codes of unique index violations.
+var duplicateKeyCodes = map[int]bool{
+	11000: true,
+	11001: true,
+	12582: true,
+}
+
+// ErrDuplicateKey is returned when inserted data has the same unique fields values as an existing record.
+var ErrDuplicateKey = errors.New("record with same unique fields exists")
+
+// ErrMandatoryIndex is returned when dropping the _id index, which every collection must have.
+var ErrMandatoryIndex = errors.New("the _id index is mandatory and cannot be dropped")
+
+// ErrWriteConcernTimeout is returned when a write was not acknowledged as the write concern requires
+// within its WTimeout. The write could still be applied, the driver error is kept wrapped underneath.
+var ErrWriteConcernTimeout = errors.New("write concern not satisfied in time")
+
+// codeWriteConcernFailed is the server code of write concern errors caused by wtimeout expiring.
+const codeWriteConcernFailed = 64
+
+// notFound maps the driver no documents error to ErrNotFound, other errors are returned as they are.
+func notFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	return err
+}
+
+// isDuplicateKey returns whether passed driver error is a unique index violation.
+func isDuplicateKey(err error) bool {
+	var errWrite mongo.WriteException
+	if errors.As(err, &errWrite) {
+		for _, errItem := range errWrite.WriteErrors {
+			if duplicateKeyCodes[errItem.Code] {
+				return true
+			}
+		}
+	}
+
+	var errBulk mongo.BulkWriteException
+	if errors.As(err, &errBulk) {
+		for _, errItem := range errBulk.WriteErrors {
+			if duplicateKeyCodes[errItem.Code] {
+				return true
+			}
+		}
+	}
+
+	var errCommand mongo.CommandError
+	if errors.As(err, &errCommand) {
+		return duplicateKeyCodes[int(errCommand.Code)]
+	}
+
+	return false
+}
+
+// isDuplicateID returns whether passed driver error is a unique index violation on _id,
+// as opposed to one on another unique index.
+func isDuplicateID(err error) bool {
+	var errWrite mongo.WriteException
+	if !errors.As(err, &errWrite) {
+		return false
+	}
+
+	for _, errItem := range errWrite.WriteErrors {
+		if duplicateKeyCodes[errItem.Code] && isIDKey(errItem) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isIDKey returns whether passed duplicate key write error is on the _id index.
+// Servers not reporting the key pattern are checked on the index name in the message.
+func isIDKey(errItem mongo.WriteError) bool {
+	keyPattern, isDocument := errItem.Raw.Lookup("keyPattern").DocumentOK()
+	if !isDocument {
+		return strings.Contains(errItem.Message, "index: _id_ ")
+	}
+
+	keys, errKeys := keyPattern.Elements()
+
+	return errKeys == nil &&
+		len(keys) == 1 &&
+		keys[0].Key() == "_id"
+}
+
+// writeConcernTimeout maps the driver write concern timeout errors to ErrWriteConcernTimeout,
+// other errors are returned as they are.
+func writeConcernTimeout(err error) error {
+	var concernError *mongo.WriteConcernError
+
+	var errWrite mongo.WriteException
+	if errors.As(err, &errWrite) {
+		concernError = errWrite.WriteConcernError
+	}
+
+	var errBulk mongo.BulkWriteException
+	if errors.As(err, &errBulk) {
+		concernError = errBulk.WriteConcernError
+	}
+
+	if concernError != nil && concernError.Code == codeWriteConcernFailed {
+		return fmt.Errorf("%w: %w", ErrWriteConcernTimeout, err)
+	}
+
+	return err
+}
@@ -0,0 +1,76 @@
+package mongoclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestNotFound(t *testing.T) {
+	errMapped := notFound(mongo.ErrNoDocuments)
+	assert.ErrorIs(t, errMapped, ErrNotFound)
+	assert.ErrorIs(t, errMapped, mongo.ErrNoDocuments)
+
+	errOther := errors.New("other")
+	assert.Equal(t, errOther, notFound(errOther))
+	assert.NoError(t, notFound(nil))
+}
+
+func TestWriteConcernTimeout(t *testing.T) {
+	errTimeout := mongo.WriteException{
+		WriteConcernError: &mongo.WriteConcernError{Code: codeWriteConcernFailed, Message: "waiting for replication timed out"},
+	}
+
+	errMapped := writeConcernTimeout(errTimeout)
+	assert.ErrorIs(t, errMapped, ErrWriteConcernTimeout)
+	assert.ErrorAs(t, errMapped, &mongo.WriteException{})
+
+	errBulk := mongo.BulkWriteException{
+		WriteConcernError: &mongo.WriteConcernError{Code: codeWriteConcernFailed},
+	}
+	assert.ErrorIs(t, writeConcernTimeout(errBulk), ErrWriteConcernTimeout)
+
+	errOther := mongo.WriteException{
+		WriteConcernError: &mongo.WriteConcernError{Code: 100},
+	}
+	assert.Equal(t, errOther, writeConcernTimeout(errOther))
+	assert.NoError(t, writeConcernTimeout(nil))
+}
+
+func TestIsDuplicateID(t *testing.T) {
+	rawKeyPattern := func(keyPattern bson.D) bson.Raw {
+		raw, errMarshal := bson.Marshal(bson.D{{Key: "keyPattern", Value: keyPattern}})
+		require.NoError(t, errMarshal)
+
+		return raw
+	}
+
+	errID := mongo.WriteException{
+		WriteErrors: mongo.WriteErrors{
+			{Code: 11000, Raw: rawKeyPattern(bson.D{{Key: "_id", Value: 1}})},
+		},
+	}
+	assert.True(t, isDuplicateID(errID))
+
+	errEmail := mongo.WriteException{
+		WriteErrors: mongo.WriteErrors{
+			{Code: 11000, Raw: rawKeyPattern(bson.D{{Key: "email", Value: 1}})},
+		},
+	}
+	assert.False(t, isDuplicateID(errEmail))
+	assert.True(t, isDuplicateKey(errEmail))
+
+	errMessage := mongo.WriteException{
+		WriteErrors: mongo.WriteErrors{
+			{Code: 11000, Message: "E11000 duplicate key error collection: db.c index: _id_ dup key: { _id: 1 }"},
+		},
+	}
+	assert.True(t, isDuplicateID(errMessage))
+
+	assert.False(t, isDuplicateID(mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 2}}}))
+	assert.False(t, isDuplicateID(errors.New("other")))
+}
@@ -0,0 +1,39 @@
+package mongoclient
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Helpers building two field comparison expressions to be used with FindExpr.
+// Field names are passed without the $ prefix.
+
+// FieldEq Helper builds expression matching records where field a equals field b.
+func FieldEq(a, b string) bson.M {
+	return fieldComparison("$eq", a, b)
+}
+
+// FieldGt Helper builds expression matching records where field a is greater than field b.
+func FieldGt(a, b string) bson.M {
+	return fieldComparison("$gt", a, b)
+}
+
+// FieldGte Helper builds expression matching records where field a is greater than or equal to field b.
+func FieldGte(a, b string) bson.M {
+	return fieldComparison("$gte", a, b)
+}
+
+// FieldLt Helper builds expression matching records where field a is less than field b.
+func FieldLt(a, b string) bson.M {
+	return fieldComparison("$lt", a, b)
+}
+
+// FieldLte Helper builds expression matching records where field a is less than or equal to field b.
+func FieldLte(a, b string) bson.M {
+	return fieldComparison("$lte", a, b)
+}
+
+func fieldComparison(operator, a, b string) bson.M {
+	return bson.M{
+		operator: bson.A{"$" + a, "$" + b},
+	}
+}
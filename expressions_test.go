@@ -0,0 +1,20 @@
+package mongoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFieldComparison(t *testing.T) {
+	assert.Equal(t,
+		bson.M{"$gt": bson.A{"$spent", "$budget"}},
+		FieldGt("spent", "budget"),
+	)
+
+	assert.Equal(t,
+		bson.M{"$lte": bson.A{"$spent", "$budget"}},
+		FieldLte("spent", "budget"),
+	)
+}
@@ -0,0 +1,173 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// codeNoReplicationEnabled is returned by replSetGetStatus on standalone instances.
+const codeNoReplicationEnabled = 76
+
+type replicaMember struct {
+	State      string    `bson:"stateStr"`
+	OptimeDate time.Time `bson:"optimeDate"`
+}
+
+// ReadFresh Method finds one record from a secondary and re-reads it from the primary
+// when the replication lag is above passed maximum.
+// As the secondary serving the read is not known the largest lag of all secondaries is used.
+// On standalone instances the record is read once.
+func (m *Client) ReadFresh(ctx context.Context, filter []byte, maxLag time.Duration) (bson.M, error) {
+	defer m.logSlow("ReadFresh", time.Now())
+
+	result, errSecondary := m.FindOne(ctx, filter, withMode(readpref.SecondaryPreferredMode))
+	if errSecondary != nil && !errors.Is(errSecondary, ErrNotFound) {
+		return nil,
+			errSecondary
+	}
+
+	lag, isReplicaSet, errLag := m.replicationLag(ctx)
+	if errLag != nil {
+		return nil,
+			errLag
+	}
+
+	if isReplicaSet && lag > maxLag {
+		primary, errPrimary := m.FindOne(ctx, filter, withMode(readpref.PrimaryMode))
+		if errPrimary != nil {
+			return nil,
+				errPrimary
+		}
+
+		return primary.(bson.M),
+			nil
+	}
+
+	if errSecondary != nil {
+		return nil,
+			errSecondary
+	}
+
+	return result.(bson.M),
+		nil
+}
+
+// FindOneResilient Method finds one record trying passed read preferences in order until a read succeeds,
+// keeping reads available during partial outages. Without read preferences primary then secondary are tried.
+// Each attempt has its own execution timeout. ErrNotFound is returned at once as it is a successful read.
+func (m *Client) FindOneResilient(ctx context.Context, filter []byte, prefs ...*readpref.ReadPref) (bson.M, error) {
+	defer m.logSlow("FindOneResilient", time.Now())
+
+	bsonFilter, errConv := m.filterFromJSON(filter)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	if len(prefs) == 0 {
+		prefs = []*readpref.ReadPref{readpref.Primary(), readpref.Secondary()}
+	}
+
+	var errRead error
+
+	for _, pref := range prefs {
+		var result bson.M
+
+		result, errRead = m.findOneWithPref(ctx, bsonFilter, pref)
+		if errRead == nil {
+			return result,
+				nil
+		}
+
+		if errors.Is(errRead, ErrNotFound) {
+			return nil,
+				errRead
+		}
+
+		m.logger().Warnf("read with preference %s failed: %s", pref, errRead)
+	}
+
+	return nil,
+		errors.Wrapf(errRead, "read failed with all %d read preferences", len(prefs))
+}
+
+func (m *Client) findOneWithPref(ctx context.Context, filter bson.M, pref *readpref.ReadPref) (bson.M, error) {
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	var result bson.M
+
+	if errFind := m.driver().
+		Database(m.Database).
+		Collection(
+			m.Collection,
+			options.Collection().SetReadPreference(pref),
+		).
+		FindOne(ctxLocal, filter).
+		Decode(&result); errFind != nil {
+		return nil,
+			notFound(errFind)
+	}
+
+	return result,
+		nil
+}
+
+// replicationLag returns the largest lag of the secondaries behind the primary and whether the deployment
+// is a replica set.
+func (m *Client) replicationLag(ctx context.Context) (time.Duration, bool, error) {
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	var status struct {
+		Members []replicaMember `bson:"members"`
+	}
+
+	if errCommand := m.driver().
+		Database("admin").
+		RunCommand(
+			ctxLocal,
+			bson.D{{Key: "replSetGetStatus", Value: 1}},
+		).
+		Decode(&status); errCommand != nil {
+		var errCode mongo.CommandError
+
+		if errors.As(errCommand, &errCode) && errCode.Code == codeNoReplicationEnabled {
+			return 0, false, nil
+		}
+
+		return 0,
+			false,
+			errors.Wrap(errCommand, "could not get replica set status")
+	}
+
+	return maxReplicationLag(status.Members),
+		true,
+		nil
+}
+
+// maxReplicationLag returns the largest lag of passed secondaries behind the primary.
+func maxReplicationLag(members []replicaMember) time.Duration {
+	var primary time.Time
+
+	for _, member := range members {
+		if member.State == "PRIMARY" {
+			primary = member.OptimeDate
+		}
+	}
+
+	var result time.Duration
+
+	for _, member := range members {
+		if member.State == "SECONDARY" {
+			result = max(result, primary.Sub(member.OptimeDate))
+		}
+	}
+
+	return result
+}
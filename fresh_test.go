@@ -0,0 +1,28 @@
+package mongoclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxReplicationLag(t *testing.T) {
+	now := time.Now()
+
+	require.Equal(t,
+		5*time.Second,
+		maxReplicationLag([]replicaMember{
+			{State: "SECONDARY", OptimeDate: now.Add(-2 * time.Second)},
+			{State: "PRIMARY", OptimeDate: now},
+			{State: "SECONDARY", OptimeDate: now.Add(-5 * time.Second)},
+			{State: "ARBITER"},
+		}),
+	)
+
+	require.Zero(t,
+		maxReplicationLag([]replicaMember{
+			{State: "PRIMARY", OptimeDate: now},
+		}),
+	)
+}
@@ -0,0 +1,53 @@
+package mongoclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindWithinPolygon Method finds the records whose GeoJSON field lies within passed polygon.
+// Polygon is one ring of [longitude, latitude] points, closed ie. the first point equals the last.
+func (m *Client) FindWithinPolygon(ctx context.Context, field string, polygon [][]float64, opts ...ReadOption) ([]bson.M, error) {
+	if errRing := validateRing(polygon); errRing != nil {
+		return nil,
+			errRing
+	}
+
+	return m.FindManyFilterBSON(
+		ctx,
+		bson.M{
+			field: bson.M{
+				"$geoWithin": bson.M{
+					"$geometry": bson.M{
+						"type":        "Polygon",
+						"coordinates": [][][]float64{polygon},
+					},
+				},
+			},
+		},
+		opts...,
+	)
+}
+
+// validateRing checks passed points form a closed GeoJSON linear ring.
+func validateRing(ring [][]float64) error {
+	if len(ring) < 4 {
+		return errors.Errorf("polygon should have at least 4 points, has %d", len(ring))
+	}
+
+	for i, point := range ring {
+		if len(point) != 2 {
+			return errors.Errorf("polygon point %d should have longitude and latitude, has %d values", i, len(point))
+		}
+	}
+
+	first, last := ring[0], ring[len(ring)-1]
+
+	if first[0] != last[0] || first[1] != last[1] {
+		return errors.Errorf("polygon is not closed, first point %v differs from last point %v", first, last)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package mongoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRing(t *testing.T) {
+	require.NoError(t,
+		validateRing([][]float64{{0, 0}, {0, 1}, {1, 1}, {0, 0}}),
+	)
+
+	require.Error(t,
+		validateRing([][]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}}),
+		"open ring",
+	)
+
+	require.Error(t,
+		validateRing([][]float64{{0, 0}, {0, 1}, {0, 0}}),
+		"too few points",
+	)
+
+	require.Error(t,
+		validateRing([][]float64{{0, 0}, {0, 1, 2}, {1, 1}, {0, 0}}),
+		"bad point",
+	)
+}
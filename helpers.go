@@ -1,11 +1,19 @@
 package mongoclient
 
 import (
+	"bytes"
 	"encoding/json"
+	"slices"
+	"time"
 
+	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// maxDocumentSize is the BSON document size limit enforced by the server.
+const maxDocumentSize = 16 * 1024 * 1024
+
 func jsonToBsonM(jsonRaw []byte) (bson.M, error) {
 	var result bson.M
 
@@ -19,3 +27,134 @@ func jsonToBsonD(jsonRaw []byte) (bson.D, error) {
 	return result,
 		json.Unmarshal(jsonRaw, &result)
 }
+
+// jsonToBsonMNumbers converts as jsonToBsonM but keeps integers as int64, other numbers being float64,
+// so that integer fields do not become doubles.
+func jsonToBsonMNumbers(jsonRaw []byte) (bson.M, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonRaw))
+	decoder.UseNumber()
+
+	var result bson.M
+
+	if errDecode := decoder.Decode(&result); errDecode != nil {
+		return nil, errDecode
+	}
+
+	for key, value := range result {
+		result[key] = convertNumbers(value)
+	}
+
+	return result,
+		nil
+}
+
+// convertNumbers replaces the JSON numbers within passed decoded value with int64 or float64.
+func convertNumbers(value any) any {
+	switch typed := value.(type) {
+	case json.Number:
+		if integer, errInt := typed.Int64(); errInt == nil {
+			return integer
+		}
+
+		float, _ := typed.Float64()
+
+		return float
+
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = convertNumbers(item)
+		}
+
+	case []any:
+		for i, item := range typed {
+			typed[i] = convertNumbers(item)
+		}
+	}
+
+	return value
+}
+
+// convertDates replaces, at any nesting level, the RFC3339 strings held by passed fields with BSON dates.
+// Strings under operators of the fields, ex. {"created":{"$gte":"2020-01-02T00:00:00Z"}}, are converted too.
+// Strings that are not RFC3339 are kept as they are.
+func convertDates(value any, fields []string) {
+	switch typed := value.(type) {
+	case bson.M:
+		convertDatesMap(typed, fields)
+
+	case map[string]any:
+		convertDatesMap(typed, fields)
+
+	case []any:
+		for _, item := range typed {
+			convertDates(item, fields)
+		}
+	}
+}
+
+func convertDatesMap(value map[string]any, fields []string) {
+	for key, item := range value {
+		if slices.Contains(fields, key) {
+			value[key] = toDate(item)
+
+			continue
+		}
+
+		convertDates(item, fields)
+	}
+}
+
+// toDate converts passed RFC3339 string, or the strings within passed operators or array, to BSON dates.
+func toDate(value any) any {
+	switch typed := value.(type) {
+	case string:
+		if parsed, errParse := time.Parse(time.RFC3339, typed); errParse == nil {
+			return primitive.NewDateTimeFromTime(parsed)
+		}
+
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = toDate(item)
+		}
+
+	case []any:
+		for i, item := range typed {
+			typed[i] = toDate(item)
+		}
+	}
+
+	return value
+}
+
+// objectIDsStrict extracts the object IDs of passed records, erroring on the first record with another _id type.
+func objectIDsStrict(records []bson.M) ([]primitive.ObjectID, error) {
+	result := make([]primitive.ObjectID, len(records))
+
+	for i, record := range records {
+		id, isObjectID := record["_id"].(primitive.ObjectID)
+		if !isObjectID {
+			return nil,
+				errors.Errorf("ID %v is not an object ID", record["_id"])
+		}
+
+		result[i] = id
+	}
+
+	return result,
+		nil
+}
+
+// checkDocumentSize returns ErrDocumentTooLarge carrying the actual size when passed document
+// would be rejected by the server.
+func checkDocumentSize(document any) error {
+	raw, errMarshal := bson.Marshal(document)
+	if errMarshal != nil {
+		return errors.Wrap(errMarshal, "could not marshal document")
+	}
+
+	if len(raw) > maxDocumentSize {
+		return errors.Wrapf(ErrDocumentTooLarge, "document has %d bytes", len(raw))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package mongoclient
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCheckDocumentSize(t *testing.T) {
+	require.NoError(t,
+		checkDocumentSize(bson.M{"Name": "mary"}),
+	)
+
+	errSize := checkDocumentSize(
+		bson.M{"Payload": strings.Repeat("x", maxDocumentSize)},
+	)
+	require.Error(t, errSize)
+	assert.True(t, errors.Is(errSize, ErrDocumentTooLarge))
+	assert.Contains(t, errSize.Error(), "16777")
+}
+
+func TestJSONToBsonMNumbers(t *testing.T) {
+	result, errConv := jsonToBsonMNumbers([]byte(`{"$set":{"Age":45,"Score":4.5,"Tags":[1,2.5],"Big":9007199254740993}}`))
+	require.NoError(t, errConv)
+
+	set := result["$set"].(map[string]any)
+	assert.Equal(t, int64(45), set["Age"])
+	assert.Equal(t, 4.5, set["Score"])
+	assert.Equal(t, []any{int64(1), 2.5}, set["Tags"])
+	assert.Equal(t, int64(9007199254740993), set["Big"])
+
+	_, errInvalid := jsonToBsonMNumbers([]byte(`{"Age":`))
+	require.Error(t, errInvalid)
+}
+
+func TestConvertDates(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	record, errConv := jsonToBsonM([]byte(`{
+		"created":"2020-01-02T03:04:05Z",
+		"meta":{"created":"2020-01-02T03:04:05Z","note":"2020-01-02T03:04:05Z"},
+		"$or":[{"created":{"$gte":"2020-01-02T03:04:05Z","$exists":true}}],
+		"updated":"yesterday"
+	}`))
+	require.NoError(t, errConv)
+
+	convertDates(record, []string{"created", "updated"})
+
+	date := primitive.NewDateTimeFromTime(created)
+
+	assert.Equal(t, date, record["created"])
+	assert.Equal(t, date, record["meta"].(map[string]any)["created"])
+	assert.Equal(t, "2020-01-02T03:04:05Z", record["meta"].(map[string]any)["note"])
+	assert.Equal(t,
+		map[string]any{"$gte": date, "$exists": true},
+		record["$or"].([]any)[0].(map[string]any)["created"],
+	)
+	assert.Equal(t, "yesterday", record["updated"])
+}
+
+func TestObjectIDsStrict(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	ids, errIDs := objectIDsStrict([]bson.M{{"_id": id}})
+	require.NoError(t, errIDs)
+	assert.Equal(t, []primitive.ObjectID{id}, ids)
+
+	_, errIDs = objectIDsStrict([]bson.M{{"_id": id}, {"_id": "generated-1"}})
+	require.Error(t, errIDs)
+}
@@ -0,0 +1,147 @@
+package mongoclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertOnceWithKey Method inserts the data under an ID derived from passed idempotency key, ex. a request ID,
+// so that retrying the insert with the same key does not create a second record.
+// Returns the ID and whether this call created the record, false meaning it was already inserted.
+// Violations of other unique indexes, ex. on an email field, are returned as ErrDuplicateKey.
+// The derived ID is an object ID without a meaningful timestamp. Data should not carry its own _id.
+func (m *Client) InsertOnceWithKey(ctx context.Context, idempotencyKey string, data []byte) (primitive.ObjectID, bool, error) {
+	defer m.logSlow("InsertOnceWithKey", time.Now())
+
+	if idempotencyKey == "" {
+		return primitive.ObjectID{}, false,
+			errors.New("idempotency key should not be empty")
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	dataM, errConv := jsonToBsonM(data)
+	if errConv != nil {
+		return primitive.ObjectID{}, false,
+			errConv
+	}
+
+	convertDates(dataM, m.DateFields)
+
+	if _, hasID := dataM["_id"]; hasID {
+		return primitive.ObjectID{}, false,
+			errors.New("data should not have an _id, it is derived from the idempotency key")
+	}
+
+	id := keyObjectID(idempotencyKey)
+	dataM["_id"] = id
+
+	if errSize := checkDocumentSize(dataM); errSize != nil {
+		return primitive.ObjectID{}, false,
+			errSize
+	}
+
+	_, errInsert := m.writeCollection().
+		InsertOne(ctxLocal, dataM)
+	if errInsert != nil {
+		if isDuplicateID(errInsert) {
+			return id, false,
+				nil
+		}
+
+		if isDuplicateKey(errInsert) {
+			return primitive.ObjectID{}, false,
+				fmt.Errorf("%w: %w", ErrDuplicateKey, errInsert)
+		}
+
+		return primitive.ObjectID{}, false,
+			writeConcernTimeout(errInsert)
+	}
+
+	return id, true,
+		nil
+}
+
+// keyObjectID derives a deterministic object ID from passed key.
+func keyObjectID(key string) primitive.ObjectID {
+	var result primitive.ObjectID
+
+	sum := sha256.Sum256([]byte(key))
+	copy(result[:], sum[:len(result)])
+
+	return result
+}
+
+// InsertOneIfUnique Method inserts the data only if no record has the same values for passed fields,
+// otherwise ErrDuplicateKey is returned.
+// The check and the insert are not atomic, concurrent inserts of the same values could both pass the check.
+// A unique index on the fields is the real guarantee, its violations are reported as ErrDuplicateKey as well.
+func (m *Client) InsertOneIfUnique(ctx context.Context, uniqueFields []string, data []byte) (primitive.ObjectID, error) {
+	defer m.logSlow("InsertOneIfUnique", time.Now())
+
+	if len(uniqueFields) == 0 {
+		return primitive.ObjectID{},
+			errors.New("unique fields should not be empty")
+	}
+
+	dataM, errConv := m.documentFromJSON(data)
+	if errConv != nil {
+		return primitive.ObjectID{},
+			errConv
+	}
+
+	objectID, errID := objectIDOf(dataM)
+	if errID != nil {
+		return primitive.ObjectID{},
+			errID
+	}
+
+	filter := bson.M{}
+
+	for _, field := range uniqueFields {
+		value, hasField := dataM[field]
+		if !hasField {
+			return primitive.ObjectID{},
+				errors.Errorf("data has no %s field", field)
+		}
+
+		filter[field] = value
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	collection := m.writeCollection()
+
+	count, errCount := collection.CountDocuments(ctxLocal, filter, options.Count().SetLimit(1))
+	if errCount != nil {
+		return primitive.ObjectID{},
+			errCount
+	}
+
+	if count > 0 {
+		return primitive.ObjectID{},
+			ErrDuplicateKey
+	}
+
+	if _, errInsert := collection.InsertOne(ctxLocal, dataM); errInsert != nil {
+		if isDuplicateKey(errInsert) {
+			return primitive.ObjectID{},
+				fmt.Errorf("%w: %w", ErrDuplicateKey, errInsert)
+		}
+
+		return primitive.ObjectID{},
+			writeConcernTimeout(errInsert)
+	}
+
+	return objectID,
+		nil
+}
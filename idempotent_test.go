@@ -0,0 +1,73 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	mongoclient "mongoclient"
+	"mongoclient/testutil"
+)
+
+func TestInsertOnceWithKey(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	id, created, errInsert := m.InsertOnceWithKey(ctx, "request-1", []byte(`{"Name":"mary"}`))
+	require.NoError(t, errInsert)
+	assert.True(t, created)
+
+	idRetry, created, errRetry := m.InsertOnceWithKey(ctx, "request-1", []byte(`{"Name":"mary"}`))
+	require.NoError(t, errRetry)
+	assert.False(t, created)
+	assert.Equal(t, id, idRetry)
+
+	records, errFind := m.FindManyFilterJSON(ctx, []byte(`{"Name":"mary"}`))
+	require.NoError(t, errFind)
+	assert.Len(t, records, 1)
+}
+
+func TestInsertOnceWithKeyOtherUniqueIndex(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, errIndex := m.EnsureIndex(ctx, bson.D{{Key: "Email", Value: 1}}, options.Index().SetUnique(true))
+	require.NoError(t, errIndex)
+
+	_, _, errInsert := m.InsertOnceWithKey(ctx, "request-1", []byte(`{"Email":"mary@example.com"}`))
+	require.NoError(t, errInsert)
+
+	_, created, errDuplicate := m.InsertOnceWithKey(ctx, "request-2", []byte(`{"Email":"mary@example.com"}`))
+	require.ErrorIs(t, errDuplicate, mongoclient.ErrDuplicateKey)
+	assert.False(t, created)
+}
+
+func TestInsertOneIfUnique(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	fields := []string{"Name", "Gender"}
+
+	id, errInsert := m.InsertOneIfUnique(ctx, fields, []byte(`{"Name":"mary","Gender":"female","Age":44}`))
+	require.NoError(t, errInsert)
+	assert.False(t, id.IsZero())
+
+	_, errDuplicate := m.InsertOneIfUnique(ctx, fields, []byte(`{"Name":"mary","Gender":"female","Age":45}`))
+	require.ErrorIs(t, errDuplicate, mongoclient.ErrDuplicateKey)
+
+	_, errOther := m.InsertOneIfUnique(ctx, fields, []byte(`{"Name":"mary","Gender":"other"}`))
+	require.NoError(t, errOther)
+
+	_, errMissing := m.InsertOneIfUnique(ctx, fields, []byte(`{"Name":"john"}`))
+	require.Error(t, errMissing)
+}
@@ -0,0 +1,160 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexStat holds usage statistics of one collection index.
+// Accesses count operations using the index since the server started tracking it.
+type IndexStat struct {
+	Name     string
+	Accesses int64
+	Since    time.Time
+}
+
+// IndexUsage Method returns usage statistics for the indexes of configured collection.
+// Counters are kept per server and reset on restart.
+func (m *Client) IndexUsage(ctx context.Context) ([]IndexStat, error) {
+	defer m.logSlow("IndexUsage", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(
+			ctxLocal,
+			mongo.Pipeline{
+				{{Key: "$indexStats", Value: bson.M{}}},
+			},
+		)
+	if errAggregate != nil {
+		return nil,
+			errAggregate
+	}
+	defer cursor.Close(ctxLocal)
+
+	var stats []struct {
+		Name     string `bson:"name"`
+		Accesses struct {
+			Ops   int64     `bson:"ops"`
+			Since time.Time `bson:"since"`
+		} `bson:"accesses"`
+	}
+
+	if errDecode := cursor.All(ctxLocal, &stats); errDecode != nil {
+		return nil,
+			errors.Wrap(errDecode, "could not decode index statistics")
+	}
+
+	result := make([]IndexStat, len(stats))
+
+	for i, stat := range stats {
+		result[i] = IndexStat{
+			Name:     stat.Name,
+			Accesses: stat.Accesses.Ops,
+			Since:    stat.Accesses.Since,
+		}
+	}
+
+	return result,
+		nil
+}
+
+// CaseInsensitive Helper returns the collation comparing strings regardless of case, as used by
+// EnsureCaseInsensitiveIndex. Pass it with WithCollation so reads hit the index.
+func CaseInsensitive() *options.Collation {
+	return &options.Collation{
+		Locale:   "en",
+		Strength: 2,
+	}
+}
+
+// EnsureIndex Method creates an index with passed keys and options on configured collection and returns its name.
+// Creating an index identical to an existing one succeeds without changes.
+// Options could be nil, ex. options.Index().SetUnique(true).SetCollation(CaseInsensitive()).
+// Reads using an index created with a collation need the same collation, see WithCollation.
+func (m *Client) EnsureIndex(ctx context.Context, keys bson.D, indexOptions *options.IndexOptions) (string, error) {
+	defer m.logSlow("EnsureIndex", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	return m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Indexes().
+		CreateOne(
+			ctxLocal,
+			mongo.IndexModel{
+				Keys:    keys,
+				Options: indexOptions,
+			},
+		)
+}
+
+// EnsureCaseInsensitiveIndex Method creates a unique case insensitive index on passed field, ex. for emails,
+// and returns its name.
+// Reads hit the index only when using the same collation, ie. WithCollation(CaseInsensitive()).
+func (m *Client) EnsureCaseInsensitiveIndex(ctx context.Context, field string) (string, error) {
+	return m.EnsureIndex(
+		ctx,
+		bson.D{{Key: field, Value: 1}},
+		options.Index().
+			SetUnique(true).
+			SetCollation(CaseInsensitive()),
+	)
+}
+
+// idIndexName is the name of the mandatory _id index.
+const idIndexName = "_id_"
+
+// ListIndexes Method returns the specifications of the indexes of configured collection,
+// ex. for migrations reconciling the desired indexes with the existing ones.
+func (m *Client) ListIndexes(ctx context.Context) ([]bson.M, error) {
+	defer m.logSlow("ListIndexes", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	cursor, errList := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Indexes().
+		List(ctxLocal)
+	if errList != nil {
+		return nil,
+			errList
+	}
+	defer cursor.Close(ctxLocal)
+
+	return m.walkMongoSet(ctxLocal, cursor)
+}
+
+// DropIndex Method removes the index with passed name from configured collection.
+// Dropping the _id index returns ErrMandatoryIndex.
+func (m *Client) DropIndex(ctx context.Context, name string) error {
+	defer m.logSlow("DropIndex", time.Now())
+
+	if name == idIndexName {
+		return ErrMandatoryIndex
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	_, errDrop := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Indexes().
+		DropOne(ctxLocal, name)
+
+	return errDrop
+}
@@ -0,0 +1,88 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mongoclient "mongoclient"
+	"mongoclient/testutil"
+)
+
+func TestIndexUsage(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	id := testInsertOne(ctx, t, m, mary)
+
+	_, errFind := m.FindByID(ctx, id)
+	require.NoError(t, errFind)
+
+	stats, errStats := m.IndexUsage(ctx)
+	require.NoError(t, errStats)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "_id_", stats[0].Name)
+	assert.Positive(t, stats[0].Accesses)
+}
+
+func TestEnsureCaseInsensitiveIndex(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	name, errIndex := m.EnsureCaseInsensitiveIndex(ctx, "Email")
+	require.NoError(t, errIndex)
+	assert.NotEmpty(t, name)
+
+	_, errInsert := m.InsertOne(ctx, []byte(`{"Email":"Mary@Example.com"}`))
+	require.NoError(t, errInsert)
+
+	_, errDuplicate := m.InsertOne(ctx, []byte(`{"Email":"mary@example.com"}`))
+	require.Error(t, errDuplicate)
+
+	records, errFind := m.FindManyFilterJSON(
+		ctx,
+		[]byte(`{"Email":"MARY@EXAMPLE.COM"}`),
+		mongoclient.WithCollation(mongoclient.CaseInsensitive()),
+	)
+	require.NoError(t, errFind)
+	assert.Len(t, records, 1)
+}
+
+func TestListDropIndexes(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, mary)
+
+	name, errEnsure := m.EnsureCaseInsensitiveIndex(ctx, "Name")
+	require.NoError(t, errEnsure)
+
+	indexNames := func() []any {
+		indexes, errList := m.ListIndexes(ctx)
+		require.NoError(t, errList)
+
+		result := make([]any, len(indexes))
+
+		for i, index := range indexes {
+			result[i] = index["name"]
+		}
+
+		return result
+	}
+
+	assert.ElementsMatch(t, []any{"_id_", name}, indexNames())
+
+	require.NoError(t, m.DropIndex(ctx, name))
+	assert.Equal(t, []any{"_id_"}, indexNames())
+
+	require.ErrorIs(t, m.DropIndex(ctx, "_id_"), mongoclient.ErrMandatoryIndex)
+	require.Error(t, m.DropIndex(ctx, "missing"))
+}
@@ -0,0 +1,208 @@
+package mongoclient
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IterationOption configures the cursor walk done by ForEach and ExportJSON.
+type IterationOption func(*iteration)
+
+type iteration struct {
+	onProgress    func(processed int64)
+	progressEvery int64
+}
+
+// WithProgress Option invokes onProgress every passed number of processed records
+// and once more at the end of the walk with the final count.
+func WithProgress(every int64, onProgress func(processed int64)) IterationOption {
+	return func(i *iteration) {
+		i.progressEvery = every
+		i.onProgress = onProgress
+	}
+}
+
+// ForEach Method invokes fn for each record matching passed filter without accumulating the result set,
+// an empty filter walks all records. Iteration stops at first error returned by fn, which is returned.
+// The execution timeout applies to opening the cursor, the walk itself is bound by passed context only.
+// The cursor is always closed, also when fn aborts or passed context is done.
+func (m *Client) ForEach(ctx context.Context, filterJSON []byte, fn func(bson.M) error, opts ...IterationOption) error {
+	bsonFilter, errConv := m.filterFromJSONOrAll(filterJSON)
+	if errConv != nil {
+		return errConv
+	}
+
+	ctxLocal, cancel := m.withDeadline(ctx)
+	defer cancel()
+
+	cursor, errFind := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Find(ctxLocal, bsonFilter)
+	if errFind != nil {
+		return errFind
+	}
+	defer m.closeCursor(cursor)
+
+	return iterateMongoSet(ctx, cursor, fn, opts...)
+}
+
+// AggregateForEach Method runs passed pipeline and invokes fn for each resulting record without accumulating them.
+// Iteration stops at first error returned by fn.
+// As for ForEach the execution timeout applies only to starting the aggregation.
+func (m *Client) AggregateForEach(ctx context.Context, pipeline mongo.Pipeline, fn func(bson.M) error, opts ...IterationOption) error {
+	ctxLocal, cancel := m.withDeadline(ctx)
+	defer cancel()
+
+	cursor, errAggregate := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Aggregate(ctxLocal, pipeline)
+	if errAggregate != nil {
+		return errAggregate
+	}
+	defer m.closeCursor(cursor)
+
+	return iterateMongoSet(ctx, cursor, fn, opts...)
+}
+
+// ExportJSON Method writes records matching passed filter to w as extended JSON, one record per line.
+func (m *Client) ExportJSON(ctx context.Context, filterJSON []byte, w io.Writer, opts ...IterationOption) error {
+	return m.ForEach(
+		ctx,
+		filterJSON,
+		func(record bson.M) error {
+			line, errMarshal := bson.MarshalExtJSON(record, false, false)
+			if errMarshal != nil {
+				return errors.Wrap(errMarshal, "could not marshal record")
+			}
+
+			_, errWrite := w.Write(append(line, '\n'))
+
+			return errWrite
+		},
+		opts...,
+	)
+}
+
+// FindManyBestEffort Method returns the records matching passed filter collected until softDeadline passes,
+// an empty filter matching all records. The boolean reports the result was truncated by the soft deadline.
+// The execution timeout and passed context still apply and fail the read when they end first.
+func (m *Client) FindManyBestEffort(ctx context.Context, filter []byte, softDeadline time.Duration) ([]bson.M, bool, error) {
+	defer m.logSlow("FindManyBestEffort", time.Now())
+
+	bsonFilter, errConv := m.filterFromJSONOrAll(filter)
+	if errConv != nil {
+		return nil,
+			false,
+			errConv
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	ctxSoft, cancelSoft := context.WithTimeout(ctxLocal, softDeadline)
+	defer cancelSoft()
+
+	var result []bson.M
+
+	if m.EmptyResultNonNil {
+		result = []bson.M{}
+	}
+
+	cursor, errFind := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Find(ctxSoft, bsonFilter)
+	if errFind != nil {
+		if ctxLocal.Err() == nil && ctxSoft.Err() != nil {
+			return result,
+				true,
+				nil
+		}
+
+		return nil,
+			false,
+			errFind
+	}
+	defer m.closeCursor(cursor)
+
+	for cursor.Next(ctxSoft) {
+		var buf bson.M
+
+		if errDecode := cursor.Decode(&buf); errDecode != nil {
+			return nil,
+				false,
+				errors.Wrap(errDecode, "could not decode into buffer")
+		}
+
+		result = append(result, buf)
+	}
+
+	if errCursor := cursor.Err(); errCursor != nil {
+		if ctxLocal.Err() == nil && ctxSoft.Err() != nil {
+			return result,
+				true,
+				nil
+		}
+
+		return nil,
+			false,
+			errCursor
+	}
+
+	return result,
+		false,
+		nil
+}
+
+// closeCursor closes passed cursor with its own context as the one of the walk could be already done.
+func (m *Client) closeCursor(cursor *mongo.Cursor) {
+	ctxClose, cancelClose := m.withDeadline(context.Background())
+	defer cancelClose()
+
+	_ = cursor.Close(ctxClose)
+}
+
+func iterateMongoSet(ctx context.Context, cursor *mongo.Cursor, fn func(bson.M) error, opts ...IterationOption) error {
+	var config iteration
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var processed int64
+
+	for cursor.Next(ctx) {
+		var buf bson.M
+
+		if errDecode := cursor.Decode(&buf); errDecode != nil {
+			return errors.Wrap(errDecode, "could not decode into buffer")
+		}
+
+		if errFn := fn(buf); errFn != nil {
+			return errFn
+		}
+
+		processed++
+
+		if config.onProgress != nil && config.progressEvery > 0 && processed%config.progressEvery == 0 {
+			config.onProgress(processed)
+		}
+	}
+
+	if errCursor := cursor.Err(); errCursor != nil {
+		return errors.Wrap(errCursor, "cursor error")
+	}
+
+	if config.onProgress != nil && (config.progressEvery <= 0 || processed%config.progressEvery != 0) {
+		config.onProgress(processed)
+	}
+
+	return nil
+}
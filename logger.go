@@ -0,0 +1,119 @@
+package mongoclient
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Logger is the logging contract of the client, satisfied by most leveled loggers through a thin adapter.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// redacted replaces the values of logged filters so that record data does not reach the logs.
+const redacted = "?"
+
+type nopLogger struct{}
+
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+
+// logger returns the configured logger, or one discarding the logs when none is configured.
+func (m *Client) logger() Logger {
+	if m.Logger == nil {
+		return nopLogger{}
+	}
+
+	return m.Logger
+}
+
+// logSlow logs a debug line with passed operation duration and warns about it when it took longer than
+// the configured threshold. Meant to be deferred at the start of the operation.
+func (m *Client) logSlow(operation string, started time.Time) {
+	elapsed := time.Since(started)
+
+	m.logger().Debugf(
+		"operation %s on %s.%s took %s",
+		operation,
+		m.Database,
+		m.Collection,
+		elapsed,
+	)
+
+	if m.SlowOpThreshold > 0 && elapsed > m.SlowOpThreshold {
+		m.logger().Warnf(
+			"slow operation %s on %s.%s took %s",
+			operation,
+			m.Database,
+			m.Collection,
+			elapsed,
+		)
+	}
+}
+
+// logFilter logs a debug line with the shape of passed filter, its values redacted.
+func (m *Client) logFilter(filter bson.M) {
+	if m.Logger == nil {
+		return
+	}
+
+	shape, _ := json.Marshal(redact(filter))
+
+	m.Logger.Debugf("filter on %s.%s: %s", m.Database, m.Collection, shape)
+}
+
+// redact returns passed filter value with its fields and operators kept and their values replaced.
+func redact(value any) any {
+	switch typed := value.(type) {
+	case bson.M:
+		return redactMap(typed)
+
+	case map[string]any:
+		return redactMap(typed)
+
+	case bson.D:
+		result := make(map[string]any, len(typed))
+
+		for _, element := range typed {
+			result[element.Key] = redact(element.Value)
+		}
+
+		return result
+
+	case bson.A:
+		return redactSlice(typed)
+
+	case []any:
+		return redactSlice(typed)
+
+	default:
+		return redacted
+	}
+}
+
+func redactSlice(value []any) []any {
+	result := make([]any, len(value))
+
+	for i, item := range value {
+		result[i] = redact(item)
+	}
+
+	return result
+}
+
+func redactMap(value map[string]any) map[string]any {
+	result := make(map[string]any, len(value))
+
+	for key, item := range value {
+		result[key] = redact(item)
+	}
+
+	return result
+}
@@ -0,0 +1,89 @@
+package mongoclient
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type testLogger struct {
+	debugs   []string
+	warnings []string
+}
+
+func (l *testLogger) Infof(string, ...any)  {}
+func (l *testLogger) Errorf(string, ...any) {}
+
+func (l *testLogger) Debugf(format string, args ...any) {
+	l.debugs = append(l.debugs, fmt.Sprintf(format, args...))
+}
+
+func (l *testLogger) Warnf(format string, args ...any) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, args...))
+}
+
+func TestLogSlow(t *testing.T) {
+	logger := &testLogger{}
+
+	m := &Client{
+		Cfg: &Cfg{
+			Database:        "testing",
+			Collection:      "persons",
+			Logger:          logger,
+			SlowOpThreshold: time.Second,
+		},
+	}
+
+	m.logSlow("FindOne", time.Now())
+	assert.Empty(t, logger.warnings)
+	if assert.Len(t, logger.debugs, 1) {
+		assert.Contains(t, logger.debugs[0], "FindOne on testing.persons")
+	}
+
+	m.logSlow("FindOne", time.Now().Add(-2*time.Second))
+	if assert.Len(t, logger.warnings, 1) {
+		assert.Contains(t, logger.warnings[0], "FindOne on testing.persons")
+	}
+
+	m.Logger = nil
+	m.logSlow("FindOne", time.Now().Add(-2*time.Second))
+}
+
+func TestLogFilter(t *testing.T) {
+	logger := &testLogger{}
+
+	m := &Client{
+		Cfg: &Cfg{
+			Database:   "testing",
+			Collection: "persons",
+			Logger:     logger,
+		},
+	}
+
+	m.logFilter(bson.M{
+		"Name": "john",
+		"$or":  []any{map[string]any{"Age": map[string]any{"$gt": 40.0}}},
+	})
+
+	if assert.Len(t, logger.debugs, 1) {
+		assert.Equal(t, `filter on testing.persons: {"$or":[{"Age":{"$gt":"?"}}],"Name":"?"}`, logger.debugs[0])
+	}
+
+	m.logFilter(bson.M{
+		"$and": bson.A{
+			bson.D{{Key: "Age", Value: bson.D{{Key: "$gte", Value: 40}}}},
+			bson.M{"Name": bson.M{"$in": bson.A{"john", "mary"}}},
+		},
+	})
+
+	if assert.Len(t, logger.debugs, 2) {
+		assert.Equal(t, `filter on testing.persons: {"$and":[{"Age":{"$gte":"?"}},{"Name":{"$in":["?","?"]}}]}`, logger.debugs[1])
+	}
+
+	m.Logger = nil
+	m.logFilter(bson.M{"Name": "john"})
+	m.logger().Debugf("no logger")
+}
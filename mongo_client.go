@@ -2,6 +2,8 @@ package mongoclient
 
 import (
 	"context"
+	"net/url"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,12 +12,14 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+	"golang.org/x/sync/errgroup"
 )
 
 // Package mongoclient is sandbox for mongo go driver.
 // Used examples as per  https://kb.objectrocket.com/mongo-db/how-to-update-a-mongodb-document-using-the-golang-driver-458.
 
-// Local context timeouts use global cfg but could be passed as argument if needed.
+// Local context timeouts use global cfg unless passed context already has a shorter deadline, see withDeadline.
 // Returning primitive.ObjectID which is a byte array.
 // TODO: verify objID, _ := primitive.ObjectIDFromHex(id) transformation
 
@@ -24,26 +28,183 @@ type Cfg struct {
 	Database   string
 	Collection string
 
+	// Username, Password and AuthSource authenticate the client without credentials in the URL,
+	// which would leak them wherever the URL is logged.
+	// They take precedence over the URL credentials, other URL authentication options, ex. the mechanism, are kept.
+	// AuthSource is the database holding the user, when empty the URL one or otherwise admin is used.
+	// Without any username AuthSource is ignored.
+	Username   string
+	Password   string
+	AuthSource string
+
+	// IDGenerator provides the ID of inserted records not having one, ex. UUIDs or ULIDs.
+	// When nil the server side generated object IDs are used.
+	IDGenerator func() any
+
+	// HedgedReads sends reads to two eligible members and uses the first response, lowering tail latency on
+	// sharded clusters. Applies only to reads routed to non primary members, ex. by WithMaxStaleness.
+	HedgedReads bool
+
+	// Logger receives the client logs, none are written when nil.
+	// Each operation logs a debug line with its duration and JSON filters their shape, with the values redacted.
+	Logger Logger
+
+	// SlowOpThreshold makes operations taking longer log a warning with their name, collection and duration.
+	// Zero disables it. Streaming operations, ex. ForEach or Watch, are not measured.
+	SlowOpThreshold time.Duration
+
+	// MaxConcurrentOps caps the operations run at the same time by the client and the clients sharing
+	// its connection, further operations wait for a free slot within their context, ex. to protect a shared cluster.
+	// Zero does not limit them. Streaming operations, ex. ForEach or Watch, are not limited.
+	MaxConcurrentOps uint
+
+	// EmptyResultNonNil makes find methods return an empty slice instead of nil when nothing matches.
+	EmptyResultNonNil bool
+
+	// DenyOperators lists operators rejected in JSON filters with ErrForbiddenOperator.
+	// When nil defaultDenyOperators apply, an empty non nil slice allows all operators.
+	DenyOperators []string
+
+	// DateFields lists the fields whose RFC3339 string values in JSON records and filters
+	// are stored and matched as BSON dates, making date range queries work on JSON paths.
+	DateFields []string
+
+	// AutoReconnect makes operations replace a disconnected driver client, ex. after Disconnect was called
+	// through a client sharing the connection, with a newly connected one before running.
+	// A failed reconnect is logged and the operation fails with the driver disconnected error.
+	AutoReconnect bool
+
+	// WriteConcern sets the acknowledgment requested for writes, ex. W "majority" with Journal for financial data.
+	// When nil the driver defaults apply. Use UseWriteConcern for writes needing another write concern.
+	WriteConcern *WriteConcern
+
+	// MaxPoolSize and MinPoolSize bound the connections kept per server, when zero the driver defaults apply,
+	// ie. at most 100 connections and none kept open. High concurrency services could need a larger pool
+	// as operations otherwise queue for a free connection.
+	MaxPoolSize uint64
+	MinPoolSize uint64
+
+	// MaxConnIdleTime closes pooled connections idle longer, when zero they are kept.
+	MaxConnIdleTime time.Duration
+
+	// SocketTimeout fails reads and writes on a connection not progressing for longer, ex. on half open sockets.
+	// When zero the operation context deadline is the only bound.
+	SocketTimeout time.Duration
+
+	// WriteAttempts is the most times InsertOne, UpdateOne and DeleteOne are tried when failing with
+	// a retryable error, ex. on a primary step down, waiting exponentially longer between attempts.
+	// Zero or one tries them once, on top of the single retry done by the driver for retryable writes.
+	// Each retry is a new command the server cannot deduplicate, so after a network error a non idempotent
+	// UpdateOne, ex. with $inc, could be applied twice. Inserted records get their _id before the first attempt.
+	WriteAttempts uint
+
+	// ServerAPIVersion pins the Stable API version, ex. "1", for deployments with API versioning, ex. Atlas.
+	// When empty no version is declared. ServerAPIStrict fails commands outside of the version
+	// and ServerAPIDeprecationErrors fails commands deprecated in it.
+	ServerAPIVersion           string
+	ServerAPIStrict            bool
+	ServerAPIDeprecationErrors bool
+
+	// AllowDropDatabase enables DropDatabase, which otherwise fails with ErrDropNotAllowed.
+	AllowDropDatabase bool
+
 	SecondsTimeoutExecution uint
 }
 
+// Validate Method checks the configuration fields needed by all operations are set.
+func (c *Cfg) Validate() error {
+	if c.URL == "" {
+		return errors.New("URL is empty")
+	}
+
+	parsed, errParse := url.Parse(c.URL)
+	if errParse != nil {
+		return errors.Wrap(errParse, "URL is not parseable")
+	}
+
+	if parsed.Scheme != "mongodb" && parsed.Scheme != "mongodb+srv" {
+		return errors.Errorf("URL scheme %q should be mongodb or mongodb+srv", parsed.Scheme)
+	}
+
+	if c.Database == "" {
+		return errors.New("database is empty")
+	}
+
+	if c.Collection == "" {
+		return errors.New("collection is empty")
+	}
+
+	if c.MaxPoolSize > 0 && c.MinPoolSize > c.MaxPoolSize {
+		return errors.Errorf("min pool size %d exceeds max pool size %d", c.MinPoolSize, c.MaxPoolSize)
+	}
+
+	if c.MaxConnIdleTime < 0 {
+		return errors.New("max connection idle time should not be negative")
+	}
+
+	if c.SocketTimeout < 0 {
+		return errors.New("socket timeout should not be negative")
+	}
+
+	if c.ServerAPIVersion != "" {
+		if errVersion := options.ServerAPIVersion(c.ServerAPIVersion).Validate(); errVersion != nil {
+			return errors.Wrap(errVersion, "invalid server API version")
+		}
+	} else if c.ServerAPIStrict || c.ServerAPIDeprecationErrors {
+		return errors.New("server API strict and deprecation errors need a server API version")
+	}
+
+	if c.Password != "" && c.Username == "" {
+		return errors.New("password is set without username")
+	}
+
+	if c.SecondsTimeoutExecution == 0 {
+		return errors.New("execution timeout should be greater than zero")
+	}
+
+	if c.WriteConcern != nil {
+		if errConcern := c.WriteConcern.validate(); errConcern != nil {
+			return errors.Wrap(errConcern, "invalid write concern")
+		}
+	}
+
+	return nil
+}
+
+// ForCollection Method returns a copy of the configuration for passed collection, all other settings inherited.
+func (c *Cfg) ForCollection(name string) *Cfg {
+	result := *c
+	result.Collection = name
+	result.DenyOperators = slices.Clone(c.DenyOperators)
+	result.DateFields = slices.Clone(c.DateFields)
+
+	return &result
+}
+
 type Client struct {
 	*Cfg
 
-	client *mongo.Client
+	conn *connection
 }
 
-type record struct {
-	Name   string
-	Gender string
-	Age    uint
+// defaultCfg returns the configuration used when none is passed, for a local server.
+// Database and collection are left empty.
+func defaultCfg() *Cfg {
+	return &Cfg{
+		URL:                     "mongodb://localhost:27017",
+		SecondsTimeoutExecution: 10,
+	}
 }
 
 // NewMongo Constructor for Mongo client.
-// Caller would need to handle connect / disconnect.
+// Passed configuration is validated, with a nil configuration defaultCfg is used.
+// Returned client is connected and pinged, caller would need to handle disconnect.
 func NewMongo(config *Cfg) (*Client, error) {
 	if config == nil {
-		// TODO: add use of default configuration.
+		config = defaultCfg()
+	} else if errValidate := config.Validate(); errValidate != nil {
+		return nil,
+			errors.Wrap(errValidate, "invalid configuration")
 	}
 
 	ctx, cancel := context.WithTimeout(
@@ -52,120 +213,366 @@ func NewMongo(config *Cfg) (*Client, error) {
 	)
 	defer cancel()
 
-	instance, errConnect := mongo.Connect(
-		ctx,
-		options.Client().ApplyURI(config.URL),
-	)
+	conn := newConnection(config)
+
+	result, errConnect := mongo.Connect(ctx, conn.clientOptions)
 	if errConnect != nil {
 		return nil, errConnect
 	}
 
-	if errPing := instance.Ping(ctx, readpref.Primary()); errPing != nil {
+	if errPing := result.Ping(ctx, readpref.Primary()); errPing != nil {
+		_ = result.Disconnect(ctx)
+
 		return nil,
 			errPing
 	}
 
-	result, errClient := mongo.NewClient(options.Client().ApplyURI(config.URL))
-	if errClient != nil || result == nil {
-		return nil,
-			errClient
-	}
+	conn.current.Store(result)
 
 	return &Client{
-			Cfg:    config,
-			client: result,
+			Cfg:  config,
+			conn: conn,
 		},
 		nil
 }
 
 // Connect Method connects client instance to configured database.
+// Clients returned by NewMongo are already connected, for them Connect does nothing.
 func (m *Client) Connect(ctx context.Context) error {
-	return m.client.Connect(ctx)
+	errConnect := m.conn.current.Load().Connect(ctx)
+	if errors.Is(errConnect, topology.ErrTopologyConnected) {
+		return nil
+	}
+
+	return errConnect
 }
 
 // Disconnect Method disconnects client from database.
 func (m *Client) Disconnect(ctx context.Context) error {
-	return m.client.Disconnect(ctx)
+	return m.conn.current.Load().Disconnect(ctx)
+}
+
+// Close Method disconnects the client for good, ex. in shutdown hooks.
+// The disconnect is bound by the execution timeout and not by a caller context, which is often already done
+// on shutdown, so that in use connections are closed in order. Clients sharing the connection are closed too
+// and are not reconnected anymore. Calls after the first one do nothing.
+func (m *Client) Close() error {
+	if !m.conn.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	m.conn.reconnecting.Lock()
+	defer m.conn.reconnecting.Unlock()
+
+	ctx, cancel := m.withDeadline(context.Background())
+	defer cancel()
+
+	return m.conn.current.Load().Disconnect(ctx)
+}
+
+// UseCollection Method returns a client for passed collection of the same database sharing this client
+// connection pool, so no new connections are opened. Its configuration is a copy, see Cfg.ForCollection.
+// Disconnecting any of the clients sharing the pool disconnects all of them.
+func (m *Client) UseCollection(name string) *Client {
+	return &Client{
+		Cfg:  m.Cfg.ForCollection(name),
+		conn: m.conn,
+	}
+}
+
+// UseDatabase Method returns a client for passed database, with the same collection name, sharing this client
+// connection pool. Combine with UseCollection to target another collection of that database.
+func (m *Client) UseDatabase(name string) *Client {
+	config := m.Cfg.ForCollection(m.Collection)
+	config.Database = name
+
+	return &Client{
+		Cfg:  config,
+		conn: m.conn,
+	}
 }
 
 // InsertOne Method inserts the data and returns the ID of the inserted data and error.
+// Use InsertOneWithID when IDs are not object IDs, ex. when configuring an IDGenerator.
+// Data whose _id, provided or generated, is not an object ID is rejected before being written.
 func (m *Client) InsertOne(ctx context.Context, data []byte) (primitive.ObjectID, error) {
-	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(m.SecondsTimeoutExecution)*time.Second)
-	defer cancel()
+	defer m.logSlow("InsertOne", time.Now())
 
-	dataM, errConv := jsonToBsonM(data)
+	dataM, errConv := m.documentFromJSON(data)
 	if errConv != nil {
-		return primitive.ObjectID{}, errConv
+		return primitive.ObjectID{},
+			errConv
 	}
 
-	collection := m.client.Database(m.Database).Collection(m.Collection)
-	if collection == nil {
+	objectID, errID := objectIDOf(dataM)
+	if errID != nil {
 		return primitive.ObjectID{},
-			errors.New("collection is nil")
+			errID
 	}
 
-	result, errInsert := collection.InsertOne(ctxLocal, dataM)
-	if errInsert != nil || result == nil {
+	if _, errInsert := m.insertDocument(ctx, dataM); errInsert != nil {
 		return primitive.ObjectID{},
 			errInsert
 	}
 
-	return result.InsertedID.(primitive.ObjectID),
+	return objectID,
+		nil
+}
+
+// InsertOneWithID Method inserts the data and returns the ID of the inserted data, of any type, and error.
+func (m *Client) InsertOneWithID(ctx context.Context, data []byte) (any, error) {
+	defer m.logSlow("InsertOneWithID", time.Now())
+
+	dataM, errConv := m.documentFromJSON(data)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	return m.insertDocument(ctx, dataM)
+}
+
+// objectIDOf returns the _id of passed document as an object ID, setting a new one when it has none.
+// Errors when the _id is of another type, ex. from an IDGenerator.
+func objectIDOf(document bson.M) (primitive.ObjectID, error) {
+	id, hasID := document["_id"]
+	if !hasID {
+		objectID := primitive.NewObjectID()
+		document["_id"] = objectID
+
+		return objectID,
+			nil
+	}
+
+	objectID, isObjectID := id.(primitive.ObjectID)
+	if !isObjectID {
+		return primitive.ObjectID{},
+			errors.Errorf("ID %v is not an object ID, use InsertOneWithID", id)
+	}
+
+	return objectID,
+		nil
+}
+
+// insertDocument inserts passed document and returns its ID.
+func (m *Client) insertDocument(ctx context.Context, dataM bson.M) (any, error) {
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	collection := m.writeCollection()
+	if collection == nil {
+		return nil,
+			errors.New("collection is nil")
+	}
+
+	return m.insertRetried(ctxLocal, dataM, func(document bson.M) (*mongo.InsertOneResult, error) {
+		return collection.InsertOne(ctxLocal, document)
+	})
+}
+
+// insertRetried inserts passed document with insert, retrying as configured by WriteAttempts.
+// A document without _id gets an object ID once, before the first attempt, so that a retry after a reply lost
+// to a network error carries the ID of the possibly written first attempt instead of inserting a second copy.
+func (m *Client) insertRetried(ctx context.Context, document bson.M, insert func(document bson.M) (*mongo.InsertOneResult, error)) (any, error) {
+	if _, hasID := document["_id"]; !hasID {
+		document["_id"] = primitive.NewObjectID()
+	}
+
+	var result *mongo.InsertOneResult
+
+	errInsert := m.retryWrite(ctx, func() error {
+		var errAttempt error
+
+		result, errAttempt = insert(document)
+
+		return errAttempt
+	})
+	if errInsert != nil || result == nil {
+		return nil,
+			writeConcernTimeout(errInsert)
+	}
+
+	return result.InsertedID,
+		nil
+}
+
+// InsertMany Method inserts passed JSON records in one batch and returns the IDs of the inserted records
+// together with their count.
+// When ordered the insert stops at the first failure, otherwise it continues past failures.
+// On partial success the IDs and count of the records actually written are returned with the error,
+// ex. for reporting 847 of 1000 inserted.
+func (m *Client) InsertMany(ctx context.Context, data [][]byte, ordered bool) ([]any, int, error) {
+	defer m.logSlow("InsertMany", time.Now())
+
+	if len(data) == 0 {
+		return nil,
+			0,
+			errors.New("no records to insert")
+	}
+
+	documents := make([]any, len(data))
+
+	for ix, item := range data {
+		document, errConv := m.documentFromJSON(item)
+		if errConv != nil {
+			return nil,
+				0,
+				errors.Wrapf(errConv, "record %d", ix)
+		}
+
+		documents[ix] = document
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	result, errInsert := m.writeCollection().
+		InsertMany(
+			ctxLocal,
+			documents,
+			options.InsertMany().SetOrdered(ordered),
+		)
+	if result == nil {
+		return nil,
+			0,
+			writeConcernTimeout(errInsert)
+	}
+
+	ids := writtenIDs(result.InsertedIDs, errInsert, ordered)
+
+	return ids,
+		len(ids),
+		writeConcernTimeout(errInsert)
+}
+
+// writtenIDs returns the IDs of the records actually written out of passed submitted IDs,
+// as the driver lists the IDs of failed records as well.
+// Unordered inserts drop the records with write errors, ordered ones stop at the first of them.
+// Without per record write errors, ex. on network errors, only a nil error means the records were written,
+// a write concern error alone leaves them written.
+func writtenIDs(submitted []any, errInsert error, ordered bool) []any {
+	if errInsert == nil {
+		return submitted
+	}
+
+	var errBulk mongo.BulkWriteException
+	if !errors.As(errInsert, &errBulk) {
+		return nil
+	}
+
+	if len(errBulk.WriteErrors) == 0 {
+		if errBulk.WriteConcernError != nil {
+			return submitted
+		}
+
+		return nil
+	}
+
+	failed := make(map[int]bool, len(errBulk.WriteErrors))
+	firstFailed := len(submitted)
+
+	for _, errItem := range errBulk.WriteErrors {
+		failed[errItem.Index] = true
+		firstFailed = min(firstFailed, errItem.Index)
+	}
+
+	if ordered {
+		return submitted[:firstFailed]
+	}
+
+	result := make([]any, 0, len(submitted))
+
+	for ix, id := range submitted {
+		if !failed[ix] {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// documentFromJSON converts passed JSON data to a record ready for insert.
+// The ID is set from the configured generator when missing and the size is checked.
+func (m *Client) documentFromJSON(data []byte) (bson.M, error) {
+	result, errConv := jsonToBsonM(data)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	convertDates(result, m.DateFields)
+
+	if _, hasID := result["_id"]; !hasID && m.IDGenerator != nil {
+		result["_id"] = m.IDGenerator()
+	}
+
+	if errSize := checkDocumentSize(result); errSize != nil {
+		return nil, errSize
+	}
+
+	return result,
 		nil
 }
 
 // FindOne Method finds data based on passed filter and returns it.
-func (m *Client) FindOne(ctx context.Context, filter []byte) (any, error) {
-	ctxLocal, cancel := context.WithTimeout(
-		ctx,
-		time.Duration(m.SecondsTimeoutExecution)*time.Second,
-	)
+// ErrNotFound is returned when nothing matches.
+func (m *Client) FindOne(ctx context.Context, filter []byte, opts ...ReadOption) (any, error) {
+	defer m.logSlow("FindOne", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
-	bsonFilter, errConv := jsonToBsonM(filter)
+	bsonFilter, errConv := m.filterFromJSON(filter)
 	if errConv != nil {
 		return nil, errConv
 	}
 
+	collection, errCollection := m.readCollection(opts...)
+	if errCollection != nil {
+		return nil, errCollection
+	}
+
 	var result bson.M
 
-	if errFind := m.client.
-		Database(m.Database).
-		Collection(m.Collection).
+	if errFind := collection.
 		FindOne(
 			ctxLocal,
 			bsonFilter,
+			newFindOneOptions(opts...),
 		).
 		Decode(&result); errFind != nil {
 		return nil,
-			errFind
+			notFound(errFind)
 	}
 
 	return result,
 		nil
 }
 
-func (m *Client) FindByID(ctx context.Context, objectID primitive.ObjectID) (any, error) {
-	ctxLocal, cancel := context.WithTimeout(
-		ctx,
-		time.Duration(m.Cfg.SecondsTimeoutExecution)*time.Second,
-	)
+// FindByID Method finds the record with passed ID, ErrNotFound is returned when there is none.
+func (m *Client) FindByID(ctx context.Context, objectID primitive.ObjectID, opts ...ReadOption) (any, error) {
+	defer m.logSlow("FindByID", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
 	bsonFilter := bson.M{"_id": bson.M{"$eq": objectID}} // variable not needed, inject directly
 
+	collection, errCollection := m.readCollection(opts...)
+	if errCollection != nil {
+		return nil,
+			errCollection
+	}
+
 	var result bson.M
-	errFind := m.client.
-		Database(m.Database).
-		Collection(m.Cfg.Collection).
+	errFind := collection.
 		FindOne(
 			ctxLocal,
 			bsonFilter,
+			newFindOneOptions(opts...),
 		).
 		Decode(&result)
 	if errFind != nil {
 		return nil,
-			errFind
+			notFound(errFind)
 	}
 
 	return result, // ex. "5d678d799139918d230cfd41"
@@ -173,25 +580,29 @@ func (m *Client) FindByID(ctx context.Context, objectID primitive.ObjectID) (any
 }
 
 // FindManyFilterJSON Method finds data based on passed ID and returns it. Could return more than one record.
-func (m *Client) FindManyFilterJSON(ctx context.Context, filterJSON []byte) ([]bson.M, error) {
-	ctxLocal, cancel := context.WithTimeout(
-		ctx,
-		time.Duration(m.Cfg.SecondsTimeoutExecution)*time.Second,
-	)
+func (m *Client) FindManyFilterJSON(ctx context.Context, filterJSON []byte, opts ...ReadOption) ([]bson.M, error) {
+	defer m.logSlow("FindManyFilterJSON", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
-	bsonFilter, errConv := jsonToBsonM(filterJSON)
+	bsonFilter, errConv := m.filterFromJSON(filterJSON)
 	if errConv != nil {
 		return nil,
 			errConv
 	}
 
-	cursor, errFind := m.client.
-		Database(m.Cfg.Database).
-		Collection(m.Cfg.Collection).
+	collection, errCollection := m.readCollection(opts...)
+	if errCollection != nil {
+		return nil,
+			errCollection
+	}
+
+	cursor, errFind := collection.
 		Find(
 			ctxLocal,
 			bsonFilter,
+			newFindOptions(opts...),
 		)
 	if errFind != nil {
 		return nil,
@@ -199,20 +610,29 @@ func (m *Client) FindManyFilterJSON(ctx context.Context, filterJSON []byte) ([]b
 	}
 	defer cursor.Close(ctxLocal)
 
-	return walkMongoSet(ctxLocal, cursor)
+	return m.walkMongoSet(ctxLocal, cursor)
 }
 
 // FindManyFilterBSON Method finds data based on passed ID and returns it. Could return more than one record.
-func (m *Client) FindManyFilterBSON(ctx context.Context, filterBSON primitive.M) ([]bson.M, error) {
-	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(m.Cfg.SecondsTimeoutExecution)*time.Second)
+func (m *Client) FindManyFilterBSON(ctx context.Context, filterBSON primitive.M, opts ...ReadOption) ([]bson.M, error) {
+	defer m.logSlow("FindManyFilterBSON", time.Now())
+
+	m.logFilter(filterBSON)
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
-	cursor, errFind := m.client.
-		Database(m.Cfg.Database).
-		Collection(m.Cfg.Collection).
+	collection, errCollection := m.readCollection(opts...)
+	if errCollection != nil {
+		return nil,
+			errCollection
+	}
+
+	cursor, errFind := collection.
 		Find(
 			ctxLocal,
 			filterBSON,
+			newFindOptions(opts...),
 		)
 	if errFind != nil {
 		return nil,
@@ -220,12 +640,18 @@ func (m *Client) FindManyFilterBSON(ctx context.Context, filterBSON primitive.M)
 	}
 	defer cursor.Close(ctxLocal)
 
-	return walkMongoSet(ctxLocal, cursor)
+	return m.walkMongoSet(ctxLocal, cursor)
 }
 
-func walkMongoSet(ctx context.Context, cursor *mongo.Cursor) ([]bson.M, error) {
+// walkMongoSet decodes all records of passed cursor.
+// Result is nil when cursor is empty unless configuration asks for an empty slice.
+func (m *Client) walkMongoSet(ctx context.Context, cursor *mongo.Cursor) ([]bson.M, error) {
 	var result []bson.M
 
+	if m.EmptyResultNonNil {
+		result = []bson.M{}
+	}
+
 	for cursor.Next(ctx) {
 		var buf bson.M
 
@@ -246,77 +672,524 @@ func walkMongoSet(ctx context.Context, cursor *mongo.Cursor) ([]bson.M, error) {
 
 // DeleteOne Method deletes one record from found.
 func (m *Client) DeleteOne(ctx context.Context, filter []byte) (any, error) {
-	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(m.Cfg.SecondsTimeoutExecution)*time.Second)
+	defer m.logSlow("DeleteOne", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
-	bsonFilter, errConv := jsonToBsonM(filter)
+	bsonFilter, errConv := m.filterFromJSON(filter)
 	if errConv != nil {
 		return nil,
 			errConv
 	}
 
-	return m.client.
-		Database(m.Cfg.Database).
-		Collection(m.Cfg.Collection).
-		DeleteOne(ctxLocal, bsonFilter)
+	var result *mongo.DeleteResult
+
+	errWrite := m.retryWrite(ctxLocal, func() error {
+		var errAttempt error
+
+		result, errAttempt = m.writeCollection().
+			DeleteOne(ctxLocal, bsonFilter)
+
+		return errAttempt
+	})
+
+	return result,
+		writeConcernTimeout(errWrite)
 }
 
 // DeleteAll Method deletes all records found matching passed filter.
 func (m *Client) DeleteAll(ctx context.Context, filter []byte) (any, error) {
-	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(m.SecondsTimeoutExecution)*time.Second)
+	defer m.logSlow("DeleteAll", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
-	bsonFilter, errConv := jsonToBsonM(filter)
+	bsonFilter, errConv := m.filterFromJSON(filter)
 	if errConv != nil {
 		return nil,
 			errConv
 	}
 
-	return m.client.
-		Database(m.Cfg.Database).
-		Collection(m.Cfg.Collection).
+	result, errWrite := m.writeCollection().
 		DeleteMany(ctxLocal, bsonFilter)
+
+	return result,
+		writeConcernTimeout(errWrite)
+}
+
+// FindOneAndDelete Method removes one record matching passed filter and returns it, in one atomic round trip,
+// ex. to pop jobs from a queue. With a sort the first record in that order is removed, ex. the oldest.
+// ErrNotFound is returned when no record matches.
+func (m *Client) FindOneAndDelete(ctx context.Context, filter bson.M, sort bson.D) (bson.M, error) {
+	defer m.logSlow("FindOneAndDelete", time.Now())
+
+	m.logFilter(filter)
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	deleteOptions := options.FindOneAndDelete()
+	if len(sort) > 0 {
+		deleteOptions.SetSort(sort)
+	}
+
+	var result bson.M
+
+	if errDelete := m.writeCollection().
+		FindOneAndDelete(ctxLocal, filter, deleteOptions).
+		Decode(&result); errDelete != nil {
+		return nil,
+			writeConcernTimeout(notFound(errDelete))
+	}
+
+	return result,
+		nil
 }
 
 // UpdateByID Method updates record with passed ID.
-func (m *Client) UpdateByID(ctx context.Context, id primitive.ObjectID, newValue bson.M) (any, error) {
-	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(m.SecondsTimeoutExecution)*time.Second)
+// With WithUpsert the outcome reports whether a record was inserted and its ID.
+func (m *Client) UpdateByID(ctx context.Context, id primitive.ObjectID, newValue bson.M, opts ...UpdateOption) (*UpdateOutcome, error) {
+	defer m.logSlow("UpdateByID", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
-	return m.client.
-		Database(m.Database).
-		Collection(m.Collection).
+	result, errWrite := m.writeCollection().
 		UpdateOne(
 			ctxLocal,
 			bson.M{"_id": bson.M{"$eq": id}},
 			newValue,
+			newUpdateOptions(opts...),
 		)
+
+	return newUpdateOutcome(result),
+		writeConcernTimeout(errWrite)
+}
+
+// UpdateByIDJSON Method updates record with passed ID using a JSON update, ex. {"$set":{"Age":45}}.
+// Integers keep an integer BSON type unlike with the JSON filters, where all numbers become doubles.
+// Update values with BSON types, ex. dates, should use UpdateByID.
+func (m *Client) UpdateByIDJSON(ctx context.Context, id primitive.ObjectID, updateJSON []byte, opts ...UpdateOption) (*UpdateOutcome, error) {
+	update, errConv := jsonToBsonMNumbers(updateJSON)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	return m.UpdateByID(ctx, id, update, opts...)
+}
+
+// UpdateByIDPipeline Method updates record with passed ID using an update pipeline, requires Mongo 4.2+.
+// Pipeline stages can use the values of other fields, ex. {$set: {Full: {$concat: ["$First", " ", "$Last"]}}}.
+func (m *Client) UpdateByIDPipeline(ctx context.Context, id primitive.ObjectID, pipeline mongo.Pipeline) (*UpdateOutcome, error) {
+	defer m.logSlow("UpdateByIDPipeline", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	result, errWrite := m.writeCollection().
+		UpdateOne(
+			ctxLocal,
+			bson.M{"_id": bson.M{"$eq": id}},
+			pipeline,
+		)
+
+	return newUpdateOutcome(result),
+		writeConcernTimeout(errWrite)
 }
 
 // UpdateOne Method updates one record from those matching passed filter.
-func (m *Client) UpdateOne(ctx context.Context, filter primitive.M, newValue bson.M) (any, error) {
-	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(m.SecondsTimeoutExecution)*time.Second)
+// With WithUpsert the outcome reports whether a record was inserted and its ID.
+// Retried as configured by WriteAttempts, which could apply a non idempotent update, ex. $inc, twice.
+func (m *Client) UpdateOne(ctx context.Context, filter primitive.M, newValue bson.M, opts ...UpdateOption) (*UpdateOutcome, error) {
+	defer m.logSlow("UpdateOne", time.Now())
+
+	m.logFilter(filter)
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
-	return m.client.
-		Database(m.Database).
-		Collection(m.Collection).
-		UpdateOne(ctxLocal, filter, newValue)
+	var result *mongo.UpdateResult
+
+	errWrite := m.retryWrite(ctxLocal, func() error {
+		var errAttempt error
+
+		result, errAttempt = m.writeCollection().
+			UpdateOne(ctxLocal, filter, newValue, newUpdateOptions(opts...))
+
+		return errAttempt
+	})
+
+	return newUpdateOutcome(result),
+		writeConcernTimeout(errWrite)
 }
 
 // UpdateMany Method updates all records that match the passed filter search.
-func (m *Client) UpdateMany(ctx context.Context, filter []byte, newValue bson.M) (any, error) {
-	ctxLocal, cancel := context.WithTimeout(ctx, time.Duration(m.SecondsTimeoutExecution)*time.Second)
+func (m *Client) UpdateMany(ctx context.Context, filter []byte, newValue bson.M) (*UpdateOutcome, error) {
+	defer m.logSlow("UpdateMany", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonFilter, errConv := m.filterFromJSON(filter)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	result, errWrite := m.writeCollection().
+		UpdateMany(ctxLocal, bsonFilter, newValue)
+
+	return newUpdateOutcome(result),
+		writeConcernTimeout(errWrite)
+}
+
+// AddToSetMany Method adds passed value to the array field of all records matching passed filter,
+// ex. to tag them. Records already holding the value are left as they are, records without the field get it
+// as an array with the value.
+func (m *Client) AddToSetMany(ctx context.Context, filter []byte, field string, value any) (*UpdateOutcome, error) {
+	defer m.logSlow("AddToSetMany", time.Now())
+
+	if field == "" {
+		return nil,
+			errors.New("field should not be empty")
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonFilter, errConv := m.filterFromJSON(filter)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	result, errWrite := m.writeCollection().
+		UpdateMany(
+			ctxLocal,
+			bsonFilter,
+			bson.M{"$addToSet": bson.M{field: value}},
+		)
+
+	return newUpdateOutcome(result),
+		writeConcernTimeout(errWrite)
+}
+
+// FindOneAndUpdate Method updates one record matching passed filter and returns it as it is after the update,
+// in one atomic round trip, ex. for counter increments. Use WithReturnBefore to get it as it was before.
+// ErrNotFound is returned when no record matches.
+func (m *Client) FindOneAndUpdate(ctx context.Context, filter, update bson.M, opts ...FindAndUpdateOption) (bson.M, error) {
+	defer m.logSlow("FindOneAndUpdate", time.Now())
+
+	m.logFilter(filter)
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	var result bson.M
+
+	if errUpdate := m.writeCollection().
+		FindOneAndUpdate(
+			ctxLocal,
+			filter,
+			update,
+			newFindAndUpdateOptions(opts...),
+		).
+		Decode(&result); errUpdate != nil {
+		return nil,
+			writeConcernTimeout(notFound(errUpdate))
+	}
+
+	return result,
+		nil
+}
+
+// UpdateOneReturning Method updates one record matching passed filter and returns it.
+// When returnNew is true the document is returned as it is after the update, otherwise as it was before.
+// ErrNotFound is returned when no record matches.
+func (m *Client) UpdateOneReturning(ctx context.Context, filter primitive.M, newValue bson.M, returnNew bool) (bson.M, error) {
+	if returnNew {
+		return m.FindOneAndUpdate(ctx, filter, newValue)
+	}
+
+	return m.FindOneAndUpdate(ctx, filter, newValue, WithReturnBefore())
+}
+
+// FindExpr Method finds data matching passed aggregation expression, ex. FieldGt("spent", "budget").
+// The expression is wrapped in $expr so it can compare fields of the same record.
+func (m *Client) FindExpr(ctx context.Context, expr bson.M, opts ...ReadOption) ([]bson.M, error) {
+	defer m.logSlow("FindExpr", time.Now())
+
+	m.logFilter(expr)
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	collection, errCollection := m.readCollection(opts...)
+	if errCollection != nil {
+		return nil,
+			errCollection
+	}
+
+	cursor, errFind := collection.
+		Find(
+			ctxLocal,
+			bson.M{"$expr": expr},
+			newFindOptions(opts...),
+		)
+	if errFind != nil {
+		return nil,
+			errFind
+	}
+	defer cursor.Close(ctxLocal)
+
+	return m.walkMongoSet(ctxLocal, cursor)
+}
+
+// UpdateManyReturningIDs Method updates all records that match the passed filter search and returns their IDs.
+// It runs two operations, a find collecting the IDs and the update, and is not atomic.
+// Records matching the filter only after the find are not updated and records no longer matching at update time
+// are still returned.
+// Errors without updating anything when a matching record has an _id that is not an object ID,
+// ex. from an IDGenerator, use UpdateMany for such records.
+func (m *Client) UpdateManyReturningIDs(ctx context.Context, filter []byte, newValue bson.M) ([]primitive.ObjectID, error) {
+	defer m.logSlow("UpdateManyReturningIDs", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonFilter, errConv := m.filterFromJSON(filter)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	collection := m.writeCollection()
+
+	cursor, errFind := collection.Find(
+		ctxLocal,
+		bsonFilter,
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if errFind != nil {
+		return nil,
+			errFind
+	}
+	defer cursor.Close(ctxLocal)
+
+	records, errWalk := m.walkMongoSet(ctxLocal, cursor)
+	if errWalk != nil {
+		return nil,
+			errWalk
+	}
+
+	result, errIDs := objectIDsStrict(records)
+	if errIDs != nil {
+		return nil,
+			errIDs
+	}
+
+	if len(result) == 0 {
+		return result,
+			nil
+	}
+
+	if _, errUpdate := collection.UpdateMany(
+		ctxLocal,
+		bson.M{
+			"$and": bson.A{
+				bsonFilter,
+				bson.M{"_id": bson.M{"$in": result}},
+			},
+		},
+		newValue,
+	); errUpdate != nil {
+		return nil,
+			writeConcernTimeout(errUpdate)
+	}
+
+	return result,
+		nil
+}
+
+// FindByFieldIn Method finds the records having passed field equal to any of passed values.
+func (m *Client) FindByFieldIn(ctx context.Context, field string, values []any, opts ...ReadOption) ([]bson.M, error) {
+	return m.FindManyFilterBSON(
+		ctx,
+		bson.M{
+			field: bson.M{"$in": values},
+		},
+		opts...,
+	)
+}
+
+// FindByIDs Method finds the records with passed IDs, in no particular order.
+func (m *Client) FindByIDs(ctx context.Context, ids []primitive.ObjectID, opts ...ReadOption) ([]bson.M, error) {
+	return m.FindManyFilterBSON(
+		ctx,
+		bson.M{
+			"_id": bson.M{"$in": ids},
+		},
+		opts...,
+	)
+}
+
+// FindByIDsChunked Method finds the records with passed IDs by splitting the IDs in chunks of chunkSize,
+// read with at most concurrency parallel queries. Records are returned in chunk order.
+// Balances the size of one $in query against the number of round trips for large sets of IDs.
+func (m *Client) FindByIDsChunked(ctx context.Context, ids []primitive.ObjectID, chunkSize, concurrency int, opts ...ReadOption) ([]bson.M, error) {
+	if chunkSize < 1 || concurrency < 1 {
+		return nil,
+			errors.Errorf("chunk size %d and concurrency %d should be positive", chunkSize, concurrency)
+	}
+
+	chunks := make([][]bson.M, (len(ids)+chunkSize-1)/chunkSize)
+
+	group, ctxGroup := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+
+	for i, chunk := range slices.Collect(slices.Chunk(ids, chunkSize)) {
+		group.Go(func() error {
+			records, errFind := m.FindByIDs(ctxGroup, chunk, opts...)
+			if errFind != nil {
+				return errFind
+			}
+
+			chunks[i] = records
+
+			return nil
+		})
+	}
+
+	if errWait := group.Wait(); errWait != nil {
+		return nil,
+			errWait
+	}
+
+	var result []bson.M
+
+	if m.EmptyResultNonNil {
+		result = []bson.M{}
+	}
+
+	for _, records := range chunks {
+		result = append(result, records...)
+	}
+
+	return result,
+		nil
+}
+
+// FindByIDsOrdered Method finds the records with passed IDs and returns them in the order of the IDs.
+// Result has one entry per passed ID, the entry is nil when no record has that ID.
+func (m *Client) FindByIDsOrdered(ctx context.Context, ids []primitive.ObjectID, opts ...ReadOption) ([]bson.M, error) {
+	records, errFind := m.FindByIDs(ctx, ids, opts...)
+	if errFind != nil {
+		return nil,
+			errFind
+	}
+
+	byID := make(map[primitive.ObjectID]bson.M, len(records))
+
+	for _, record := range records {
+		if id, isObjectID := record["_id"].(primitive.ObjectID); isObjectID {
+			byID[id] = record
+		}
+	}
+
+	result := make([]bson.M, len(ids))
+
+	for i, id := range ids {
+		result[i] = byID[id]
+	}
+
+	return result,
+		nil
+}
+
+// CompareAndSet Method sets passed field of the record with passed ID to newValue only if it currently equals expected.
+// Returns whether the record matched, ie. the swap happened.
+func (m *Client) CompareAndSet(ctx context.Context, id primitive.ObjectID, field string, expected, newValue any) (bool, error) {
+	defer m.logSlow("CompareAndSet", time.Now())
+
+	filter := bson.M{
+		"_id": id,
+		field: expected,
+	}
+
+	m.logFilter(filter)
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	result, errUpdate := m.writeCollection().
+		UpdateOne(
+			ctxLocal,
+			filter,
+			bson.M{
+				"$set": bson.M{field: newValue},
+			},
+		)
+	if errUpdate != nil {
+		return false,
+			writeConcernTimeout(errUpdate)
+	}
+
+	return result.MatchedCount > 0,
+		nil
+}
+
+// FindByArraySize Method finds the records whose array field has exactly passed number of elements.
+func (m *Client) FindByArraySize(ctx context.Context, field string, size int, opts ...ReadOption) ([]bson.M, error) {
+	return m.FindManyFilterBSON(
+		ctx,
+		bson.M{
+			field: bson.M{"$size": size},
+		},
+		opts...,
+	)
+}
+
+// FindByArraySizeRange Method finds the records whose array field has between minSize and maxSize elements, inclusive.
+// Records where field is missing or not an array are not matched.
+func (m *Client) FindByArraySizeRange(ctx context.Context, field string, minSize, maxSize int, opts ...ReadOption) ([]bson.M, error) {
+	size := bson.M{
+		"$cond": bson.A{
+			bson.M{"$isArray": "$" + field},
+			bson.M{"$size": "$" + field},
+			-1,
+		},
+	}
+
+	return m.FindExpr(
+		ctx,
+		bson.M{
+			"$and": bson.A{
+				bson.M{"$gte": bson.A{size, minSize}},
+				bson.M{"$lte": bson.A{size, maxSize}},
+			},
+		},
+		opts...,
+	)
+}
+
+// Distinct Method returns the distinct values of passed field among the records matching passed filter.
+// A nil filter looks across all records. Values keep their BSON types, ex. numbers stay numbers.
+func (m *Client) Distinct(ctx context.Context, field string, filterJSON []byte) ([]any, error) {
+	defer m.logSlow("Distinct", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
 	defer cancel()
 
-	bsonFilter, errConv := jsonToBsonM(filter)
+	bsonFilter, errConv := m.filterFromJSONOrAll(filterJSON)
 	if errConv != nil {
 		return nil,
 			errConv
 	}
 
-	return m.client.
+	return m.driver().
 		Database(m.Database).
 		Collection(m.Collection).
-		UpdateMany(ctxLocal, bsonFilter, newValue)
+		Distinct(ctxLocal, field, bsonFilter)
 }
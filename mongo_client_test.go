@@ -1,18 +1,33 @@
-package mongoclient
+package mongoclient_test
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/TudorHulban/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	mongoclient "mongoclient"
+	"mongoclient/testutil"
 )
 
+type record struct {
+	Name   string `bson:"Name"`
+	Gender string `bson:"Gender"`
+	Age    uint   `bson:"Age"`
+}
+
 var (
 	john = record{
 		Name:   "john",
@@ -29,16 +44,7 @@ var (
 	_logger = log.New(log.DEBUG, os.Stderr, true)
 )
 
-func testCfg() *Cfg {
-	return &Cfg{
-		SecondsTimeoutExecution: 3,
-		URL:                     "mongodb://localhost:27017",
-		Database:                "testing",
-		Collection:              "persons",
-	}
-}
-
-func testInsertOne(ctx context.Context, t *testing.T, m *Client, value record) primitive.ObjectID {
+func testInsertOne(ctx context.Context, t *testing.T, m *mongoclient.Client, value record) primitive.ObjectID {
 	valueMarshalled, errMarshall := json.Marshal(value)
 	require.NoError(t, errMarshall)
 
@@ -54,37 +60,21 @@ func testInsertOne(ctx context.Context, t *testing.T, m *Client, value record) p
 }
 
 func TestInsertOne(t *testing.T) {
-	client, errNew := NewMongo(testCfg())
-	require.NoError(t,
-		errNew,
-		"connection to Mongo DB issues",
-	)
-	require.NotNil(t, client)
+	client, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	ctx := context.Background()
 
-	require.NoError(t,
-		client.Connect(ctx),
-		"could not connect",
-	)
-	defer client.Disconnect(ctx)
-
 	require.NotEmpty(t,
 		testInsertOne(ctx, t, client, john),
 	)
 }
 
 func TestFindByID(t *testing.T) {
-	client, errNew := NewMongo(testCfg())
-	require.NoError(t, errNew, "connection to Mongo DB issues")
-	require.NotNil(t, client)
+	client, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	ctx := context.Background()
-	require.NoError(t,
-		client.Connect(ctx),
-		"could not connect",
-	)
-	defer client.Disconnect(ctx)
 
 	johnID := testInsertOne(ctx, t, client, john)
 
@@ -96,13 +86,12 @@ func TestFindByID(t *testing.T) {
 }
 
 func TestFindOneRecord(t *testing.T) {
-	m, errNew := NewMongo(testCfg())
-	require.NoError(t, errNew, "connection to Mongo DB issues")
-	require.NotNil(t, m)
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	ctx := context.Background()
-	require.NoError(t, m.Connect(ctx), "could not connect")
-	defer m.Disconnect(ctx)
+
+	testInsertOne(ctx, t, m, john)
 
 	jsonJohn, errMarshall := json.Marshal(john)
 	require.Nil(t, errMarshall)
@@ -113,27 +102,21 @@ func TestFindOneRecord(t *testing.T) {
 }
 
 func TestFindOneNoRecord(t *testing.T) {
-	m, errNew := NewMongo(testCfg())
-	require.NoError(t, errNew, "connection to Mongo DB issues")
-	require.NotNil(t, m)
-
-	ctx := context.Background()
-	require.NoError(t, m.Connect(ctx), "could not connect")
-	defer m.Disconnect(ctx)
+	_, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	// TODO: implementation.
 }
 
 // TestFindMany Should find several records.
 func TestFindManyJSON(t *testing.T) {
-	m, errNew := NewMongo(testCfg())
-	require.NoError(t, errNew, "connection to Mongo DB issues")
-	require.NotNil(t, m)
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	ctx := context.Background()
-	require.NoError(t, m.Connect(ctx), "could not connect")
-	defer m.Disconnect(ctx)
 
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
 	testInsertOne(ctx, t, m, mary)
 
 	age := struct{ age uint }{
@@ -153,18 +136,19 @@ func TestFindManyJSON(t *testing.T) {
 
 // TestDeleteOne Should delete one record provided the passed filter.
 func TestDeleteOne(t *testing.T) {
-	m, errNew := NewMongo(testCfg())
-	require.NoError(t, errNew, "connection to Mongo DB issues")
-	require.NotNil(t, m)
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	ctx := context.Background()
-	require.NoError(t, m.Connect(ctx), "could not connect")
-	defer m.Disconnect(ctx)
+
+	testInsertOne(ctx, t, m, mary)
+	testInsertOne(ctx, t, m, mary)
 
 	jsonMary, errMarshall := json.Marshal(mary)
 	require.NoError(t, errMarshall)
 
 	manyMaryBefore, errMany := m.FindManyFilterJSON(ctx, jsonMary)
+	require.NoError(t, errMany)
 
 	_, errDelete := m.DeleteOne(ctx, jsonMary)
 	require.NoError(t, errDelete)
@@ -176,13 +160,13 @@ func TestDeleteOne(t *testing.T) {
 
 // TestDeleteAll Should delete all records for passed filter.
 func TestDeleteAll(t *testing.T) {
-	m, errNew := NewMongo(testCfg())
-	require.NoError(t, errNew, "connection to Mongo DB issues")
-	require.NotNil(t, m)
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	ctx := context.Background()
-	require.NoError(t, m.Connect(ctx), "could not connect")
-	defer m.Disconnect(ctx)
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, john)
 
 	raw, errMarshall := json.Marshal(john)
 	require.NoError(t, errMarshall)
@@ -197,13 +181,10 @@ func TestDeleteAll(t *testing.T) {
 
 // TestUpdateByID Should update the record with passed ID.
 func TestUpdateByID(t *testing.T) {
-	m, errNew := NewMongo(testCfg())
-	require.NoError(t, errNew, "connection to Mongo DB issues")
-	require.NotNil(t, m)
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	ctx := context.Background()
-	require.NoError(t, m.Connect(ctx), "could not connect")
-	defer m.Disconnect(ctx)
 
 	id := testInsertOne(ctx, t, m, mary)
 	bsonUpdate := bson.M{"$set": bson.M{"Name": "mary", "Gender": "female", "Age": 45}} // just changing the age
@@ -227,13 +208,10 @@ func TestUpdateByID(t *testing.T) {
 
 // TestUpdateOne Should update a record given passed data.
 func TestUpdateOne(t *testing.T) {
-	m, errNew := NewMongo(testCfg())
-	require.NoError(t, errNew, "connection to Mongo DB issues")
-	require.NotNil(t, m)
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
 
 	ctx := context.Background()
-	require.Nil(t, m.Connect(ctx), "could not connect")
-	defer m.Disconnect(ctx)
 
 	testInsertOne(ctx, t, m, mary)
 
@@ -254,3 +232,790 @@ func TestUpdateOne(t *testing.T) {
 	_, errUpdate := m.UpdateOne(ctx, bsonFilter, bsonUpdate)
 	require.NoError(t, errUpdate)
 }
+
+// TestUpdateOneReturning Should return the updated record in the same round trip.
+func TestUpdateOneReturning(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	id := testInsertOne(ctx, t, m, mary)
+
+	bsonUpdate := bson.M{
+		"$set": bson.M{
+			"Age": 46,
+		},
+	}
+
+	updated, errUpdate := m.UpdateOneReturning(ctx, bson.M{"_id": id}, bsonUpdate, true)
+	require.NoError(t, errUpdate)
+	assert.EqualValues(t, 46, updated["Age"])
+}
+
+// TestFindExpr Should find records comparing two of their fields.
+func TestFindExpr(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, mary)
+
+	records, errFind := m.FindExpr(ctx, mongoclient.FieldEq("Age", "Age"))
+	require.NoError(t, errFind)
+	assert.NotEmpty(t, records)
+}
+
+// TestForEachProgress Should walk records reporting progress.
+func TestForEachProgress(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, mary)
+	testInsertOne(ctx, t, m, mary)
+
+	var walked, reported int64
+
+	errWalk := m.ForEach(
+		ctx,
+		[]byte(`{"Name":"mary"}`),
+		func(bson.M) error {
+			walked++
+
+			return nil
+		},
+		mongoclient.WithProgress(1, func(processed int64) {
+			reported = processed
+		}),
+	)
+	require.NoError(t, errWalk)
+	assert.GreaterOrEqual(t, walked, int64(2))
+	assert.Equal(t, walked, reported)
+}
+
+// TestFindManyBestEffort Should return all records within the soft deadline and report truncation past it.
+func TestFindManyBestEffort(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+
+	records, isTruncated, errFind := m.FindManyBestEffort(ctx, nil, time.Minute)
+	require.NoError(t, errFind)
+	assert.False(t, isTruncated)
+	assert.Len(t, records, 2)
+
+	partial, isPartial, errPartial := m.FindManyBestEffort(ctx, nil, time.Nanosecond)
+	require.NoError(t, errPartial)
+	assert.True(t, isPartial)
+	assert.Less(t, len(partial), 2)
+}
+
+// TestForEachStopsEarly Should stop the walk at the first error returned by the callback.
+func TestForEachStopsEarly(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for range 5 {
+		testInsertOne(ctx, t, m, john)
+	}
+
+	errStop := errors.New("stop")
+
+	var walked int
+
+	errWalk := m.ForEach(
+		ctx,
+		nil,
+		func(bson.M) error {
+			walked++
+
+			if walked == 2 {
+				return errStop
+			}
+
+			return nil
+		},
+	)
+	require.ErrorIs(t, errWalk, errStop)
+	assert.Equal(t, 2, walked)
+}
+
+// TestUpdateManyReturningIDs Should return the IDs of the updated records.
+func TestUpdateManyReturningIDs(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	id1 := testInsertOne(ctx, t, m, mary)
+	id2 := testInsertOne(ctx, t, m, mary)
+	testInsertOne(ctx, t, m, john)
+
+	ids, errUpdate := m.UpdateManyReturningIDs(
+		ctx,
+		[]byte(`{"Name":"mary"}`),
+		bson.M{"$set": bson.M{"Age": 50}},
+	)
+	require.NoError(t, errUpdate)
+	assert.ElementsMatch(t, []primitive.ObjectID{id1, id2}, ids)
+}
+
+// TestAddToSetMany Should add the value once to the matching records only.
+func TestAddToSetMany(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	idMary := testInsertOne(ctx, t, m, mary)
+	idJohn := testInsertOne(ctx, t, m, john)
+
+	outcome, errTag := m.AddToSetMany(ctx, []byte(`{"Name":"mary"}`), "Tags", "vip")
+	require.NoError(t, errTag)
+	assert.EqualValues(t, 1, outcome.Modified)
+
+	again, errAgain := m.AddToSetMany(ctx, []byte(`{"Name":"mary"}`), "Tags", "vip")
+	require.NoError(t, errAgain)
+	assert.EqualValues(t, 1, again.Matched)
+	assert.Zero(t, again.Modified)
+
+	tagged, errTagged := m.FindByID(ctx, idMary)
+	require.NoError(t, errTagged)
+	assert.Equal(t, bson.A{"vip"}, tagged.(bson.M)["Tags"])
+
+	untagged, errUntagged := m.FindByID(ctx, idJohn)
+	require.NoError(t, errUntagged)
+	assert.NotContains(t, untagged.(bson.M), "Tags")
+
+	_, errField := m.AddToSetMany(ctx, []byte(`{}`), "", "vip")
+	require.Error(t, errField)
+}
+
+// TestFindByFieldIn Should find the records matching any of the passed values.
+func TestFindByFieldIn(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+	testInsertOne(ctx, t, m, record{Name: "jane", Gender: "female", Age: 30})
+
+	records, errFind := m.FindByFieldIn(ctx, "Name", []any{"john", "mary"})
+	require.NoError(t, errFind)
+	assert.Len(t, records, 2)
+}
+
+// TestFindManySorted Should return the records in the requested order.
+func TestFindManySorted(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, record{Name: "ann", Gender: "female", Age: 30})
+	testInsertOne(ctx, t, m, record{Name: "bob", Gender: "male", Age: 40})
+	testInsertOne(ctx, t, m, record{Name: "cid", Gender: "male", Age: 30})
+
+	records, errFind := m.FindManySorted(ctx, []byte(`{}`), "Age:-1,Name:1")
+	require.NoError(t, errFind)
+	require.Len(t, records, 3)
+	assert.Equal(t, "bob", records[0]["Name"])
+	assert.Equal(t, "ann", records[1]["Name"])
+	assert.Equal(t, "cid", records[2]["Name"])
+}
+
+// TestFindByIDsOrdered Should return the records in the order of the IDs with nil for missing ones.
+func TestFindByIDsOrdered(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	idJohn := testInsertOne(ctx, t, m, john)
+	idMary := testInsertOne(ctx, t, m, mary)
+
+	records, errFind := m.FindByIDsOrdered(ctx, []primitive.ObjectID{idMary, primitive.NewObjectID(), idJohn})
+	require.NoError(t, errFind)
+	require.Len(t, records, 3)
+	assert.Equal(t, idMary, records[0]["_id"])
+	assert.Nil(t, records[1])
+	assert.Equal(t, idJohn, records[2]["_id"])
+}
+
+// TestFindManyEmptyResultNonNil Should return an empty but non nil slice when configured.
+func TestFindManyEmptyResultNonNil(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	records, errFind := m.FindManyFilterJSON(ctx, []byte(`{"Name":"nobody"}`))
+	require.NoError(t, errFind)
+	assert.Nil(t, records)
+
+	m.EmptyResultNonNil = true
+
+	records, errFind = m.FindManyFilterJSON(ctx, []byte(`{"Name":"nobody"}`))
+	require.NoError(t, errFind)
+	assert.NotNil(t, records)
+	assert.Empty(t, records)
+}
+
+// TestInsertOneIDGenerator Should use the configured generator for records without ID.
+func TestInsertOneIDGenerator(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var generated int
+
+	m.IDGenerator = func() any {
+		generated++
+
+		return fmt.Sprintf("generated-%d", generated)
+	}
+
+	id, errInsert := m.InsertOneWithID(ctx, []byte(`{"Name":"mary"}`))
+	require.NoError(t, errInsert)
+	assert.Equal(t, "generated-1", id)
+
+	idOwn, errInsert := m.InsertOneWithID(ctx, []byte(`{"_id":"own","Name":"john"}`))
+	require.NoError(t, errInsert)
+	assert.Equal(t, "own", idOwn)
+
+	_, errObjectID := m.InsertOne(ctx, []byte(`{"Name":"jane"}`))
+	require.Error(t, errObjectID)
+
+	_, errUnique := m.InsertOneIfUnique(ctx, []string{"Name"}, []byte(`{"Name":"jane"}`))
+	require.Error(t, errUnique)
+
+	records, errFind := m.FindManyFilterJSON(ctx, []byte(`{"Name":"jane"}`))
+	require.NoError(t, errFind)
+	assert.Empty(t, records)
+}
+
+// TestCompareAndSet Should update only when the current value is the expected one.
+func TestCompareAndSet(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	id := testInsertOne(ctx, t, m, mary)
+
+	swapped, errSwap := m.CompareAndSet(ctx, id, "Gender", "male", "other")
+	require.NoError(t, errSwap)
+	assert.False(t, swapped)
+
+	swapped, errSwap = m.CompareAndSet(ctx, id, "Gender", "female", "other")
+	require.NoError(t, errSwap)
+	assert.True(t, swapped)
+}
+
+// TestReconcileMany Should only write the records that changed.
+func TestReconcileMany(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+
+	inserted, updated, unchanged, errReconcile := m.ReconcileMany(
+		ctx,
+		"Name",
+		[][]byte{
+			[]byte(`{"Name":"john","Gender":"male","Age":44}`),
+			[]byte(`{"Name":"mary","Gender":"female","Age":45}`),
+			[]byte(`{"Name":"jane","Gender":"female","Age":30}`),
+		},
+	)
+	require.NoError(t, errReconcile)
+	assert.EqualValues(t, 1, inserted)
+	assert.EqualValues(t, 1, updated)
+	assert.EqualValues(t, 1, unchanged)
+}
+
+// TestFindByArraySize Should match records on the number of array elements.
+func TestFindByArraySize(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, raw := range []string{
+		`{"Name":"ann","Tags":[]}`,
+		`{"Name":"bob","Tags":["a"]}`,
+		`{"Name":"cid","Tags":["a","b"]}`,
+		`{"Name":"dan","Tags":["a","b","c"]}`,
+		`{"Name":"eve"}`,
+	} {
+		_, errInsert := m.InsertOne(ctx, []byte(raw))
+		require.NoError(t, errInsert)
+	}
+
+	exact, errExact := m.FindByArraySize(ctx, "Tags", 2)
+	require.NoError(t, errExact)
+	require.Len(t, exact, 1)
+	assert.Equal(t, "cid", exact[0]["Name"])
+
+	ranged, errRange := m.FindByArraySizeRange(ctx, "Tags", 1, 2)
+	require.NoError(t, errRange)
+	assert.Len(t, ranged, 2)
+}
+
+// TestUpdateOneUpsert Should insert the record when none matches.
+func TestUpdateOneUpsert(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	result, errUpdate := m.UpdateOne(
+		ctx,
+		bson.M{"Name": "jane"},
+		bson.M{"$set": bson.M{"Age": 30}},
+		mongoclient.WithUpsert(),
+	)
+	require.NoError(t, errUpdate)
+
+	assert.EqualValues(t, 1, result.Upserted)
+	assert.NotNil(t, result.UpsertedID)
+
+	id := primitive.NewObjectID()
+
+	result, errUpdate = m.UpdateByID(ctx, id, bson.M{"$set": bson.M{"Name": "joe"}}, mongoclient.WithUpsert())
+	require.NoError(t, errUpdate)
+	assert.Equal(t, id, result.UpsertedID)
+}
+
+// TestFindManyWithSort Should return the records in the order of the sort document.
+func TestFindManyWithSort(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, record{Name: "ann", Gender: "female", Age: 30})
+	testInsertOne(ctx, t, m, record{Name: "bob", Gender: "male", Age: 40})
+
+	records, errFind := m.FindManyFilterJSON(
+		ctx,
+		[]byte(`{}`),
+		mongoclient.WithSort(bson.D{{Key: "Age", Value: -1}}),
+	)
+	require.NoError(t, errFind)
+	require.Len(t, records, 2)
+	assert.Equal(t, "bob", records[0]["Name"])
+
+	youngest, errFindOne := m.FindOne(
+		ctx,
+		[]byte(`{}`),
+		mongoclient.WithSort(bson.D{{Key: "Age", Value: 1}}),
+	)
+	require.NoError(t, errFindOne)
+	assert.Equal(t, "ann", youngest.(bson.M)["Name"])
+}
+
+// TestFindWithinPolygon Should find the locations inside the polygon.
+func TestFindWithinPolygon(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, errInside := m.InsertOne(ctx, []byte(`{"Name":"inside","Location":{"type":"Point","coordinates":[0.5,0.5]}}`))
+	require.NoError(t, errInside)
+
+	_, errOutside := m.InsertOne(ctx, []byte(`{"Name":"outside","Location":{"type":"Point","coordinates":[5,5]}}`))
+	require.NoError(t, errOutside)
+
+	records, errFind := m.FindWithinPolygon(
+		ctx,
+		"Location",
+		[][]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}},
+	)
+	require.NoError(t, errFind)
+	require.Len(t, records, 1)
+	assert.Equal(t, "inside", records[0]["Name"])
+
+	_, errOpen := m.FindWithinPolygon(ctx, "Location", [][]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}})
+	require.Error(t, errOpen)
+}
+
+// TestFindProjected Should return only the projected fields.
+func TestFindProjected(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+
+	filter := []byte(`{"Name":"` + john.Name + `"}`)
+
+	projected, errProjected := m.FindOneProjected(ctx, filter, bson.M{"Name": 1, "_id": 0})
+	require.NoError(t, errProjected)
+	assert.Equal(t, bson.M{"Name": john.Name}, projected)
+
+	whole, errWhole := m.FindOneProjected(ctx, filter, bson.M{})
+	require.NoError(t, errWhole)
+	assert.Contains(t, whole, "_id")
+	assert.Contains(t, whole, "Age")
+
+	many, errMany := m.FindManyProjected(ctx, []byte(`{}`), bson.M{"Age": 1})
+	require.NoError(t, errMany)
+	require.Len(t, many, 2)
+
+	for _, record := range many {
+		assert.Len(t, record, 2)
+		assert.Contains(t, record, "_id")
+		assert.Contains(t, record, "Age")
+	}
+}
+
+// TestUpdateByIDPipeline Should set a field from the values of other fields.
+func TestUpdateByIDPipeline(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	id := testInsertOne(ctx, t, m, john)
+
+	result, errUpdate := m.UpdateByIDPipeline(
+		ctx,
+		id,
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"Description": bson.M{"$concat": bson.A{"$Name", " ", "$Gender"}},
+			}}},
+		},
+	)
+	require.NoError(t, errUpdate)
+	require.EqualValues(t, 1, result.Modified)
+
+	updated, errFind := m.FindByID(ctx, id)
+	require.NoError(t, errFind)
+	assert.Equal(t, "john male", updated.(bson.M)["Description"])
+}
+
+// TestReadFresh Should read the record when the lag is within bounds and when it is not.
+func TestReadFresh(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t, mongodb.WithReplicaSet("rs0"))
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	filter := []byte(`{"Name":"` + john.Name + `"}`)
+
+	fresh, errFresh := m.ReadFresh(ctx, filter, time.Minute)
+	require.NoError(t, errFresh)
+	assert.Equal(t, john.Name, fresh["Name"])
+
+	_, errMissing := m.ReadFresh(ctx, []byte(`{"Name":"nobody"}`), time.Minute)
+	require.ErrorIs(t, errMissing, mongoclient.ErrNotFound)
+}
+
+// TestFindOneResilient Should fall back to the next read preference when a read fails.
+func TestFindOneResilient(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t, mongodb.WithReplicaSet("rs0"))
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	filter := []byte(`{"Name":"` + john.Name + `"}`)
+
+	// Single member replica set has no secondary to read from.
+	found, errFind := m.FindOneResilient(ctx, filter, readpref.Secondary(), readpref.Primary())
+	require.NoError(t, errFind)
+	assert.Equal(t, john.Name, found["Name"])
+
+	_, errMissing := m.FindOneResilient(ctx, []byte(`{"Name":"nobody"}`))
+	require.ErrorIs(t, errMissing, mongoclient.ErrNotFound)
+
+	_, errAll := m.FindOneResilient(ctx, filter, readpref.Secondary())
+	require.Error(t, errAll)
+}
+
+// TestDistinct Should return the distinct values keeping their types.
+func TestDistinct(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+	testInsertOne(ctx, t, m, record{Name: "eve", Gender: "female", Age: 30})
+
+	genders, errGenders := m.Distinct(ctx, "Gender", nil)
+	require.NoError(t, errGenders)
+	assert.ElementsMatch(t, []any{"female", "male"}, genders)
+
+	ages, errAges := m.Distinct(ctx, "Age", []byte(`{"Gender":"female"}`))
+	require.NoError(t, errAges)
+	require.Len(t, ages, 2)
+
+	for _, age := range ages {
+		assert.IsType(t, float64(0), age)
+	}
+}
+
+// TestFindOneAndUpdate Should return the incremented counter, or its previous value when asked.
+func TestFindOneAndUpdate(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	id := testInsertOne(ctx, t, m, john)
+
+	increment := bson.M{"$inc": bson.M{"Age": 1}}
+
+	after, errAfter := m.FindOneAndUpdate(ctx, bson.M{"_id": id}, increment)
+	require.NoError(t, errAfter)
+	assert.EqualValues(t, john.Age+1, after["Age"])
+
+	before, errBefore := m.FindOneAndUpdate(ctx, bson.M{"_id": id}, increment, mongoclient.WithReturnBefore())
+	require.NoError(t, errBefore)
+	assert.EqualValues(t, john.Age+1, before["Age"])
+
+	_, errMissing := m.FindOneAndUpdate(ctx, bson.M{"Name": "nobody"}, increment)
+	require.ErrorIs(t, errMissing, mongoclient.ErrNotFound)
+
+	_, errMissingReturning := m.UpdateOneReturning(ctx, bson.M{"Name": "nobody"}, increment, true)
+	require.ErrorIs(t, errMissingReturning, mongoclient.ErrNotFound)
+}
+
+// TestFindOneAndDelete Should pop the records in sort order and then report none is left.
+func TestFindOneAndDelete(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, record{Name: "first", Age: 1})
+	testInsertOne(ctx, t, m, record{Name: "second", Age: 2})
+
+	byAge := bson.D{{Key: "Age", Value: 1}}
+
+	first, errFirst := m.FindOneAndDelete(ctx, bson.M{}, byAge)
+	require.NoError(t, errFirst)
+	assert.Equal(t, "first", first["Name"])
+
+	second, errSecond := m.FindOneAndDelete(ctx, bson.M{}, byAge)
+	require.NoError(t, errSecond)
+	assert.Equal(t, "second", second["Name"])
+
+	_, errEmpty := m.FindOneAndDelete(ctx, bson.M{}, nil)
+	require.ErrorIs(t, errEmpty, mongoclient.ErrNotFound)
+}
+
+// TestFindOneNotFound Should return ErrNotFound wrapping the driver error.
+func TestFindOneNotFound(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, errFind := m.FindOne(ctx, []byte(`{"Name":"nobody"}`))
+	require.ErrorIs(t, errFind, mongoclient.ErrNotFound)
+	require.ErrorIs(t, errFind, mongo.ErrNoDocuments)
+
+	_, errFindByID := m.FindByID(ctx, primitive.NewObjectID())
+	require.ErrorIs(t, errFindByID, mongoclient.ErrNotFound)
+}
+
+// TestUpdateByIDJSON Should keep integer values as integers.
+func TestUpdateByIDJSON(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	id := testInsertOne(ctx, t, m, john)
+
+	_, errUpdate := m.UpdateByIDJSON(ctx, id, []byte(`{"$set":{"Age":45,"Height":1.8}}`))
+	require.NoError(t, errUpdate)
+
+	updated, errFind := m.FindByID(ctx, id)
+	require.NoError(t, errFind)
+	assert.Equal(t, int64(45), updated.(bson.M)["Age"])
+	assert.Equal(t, 1.8, updated.(bson.M)["Height"])
+}
+
+// TestFindByIDsChunked Should find all the records with passed IDs over several chunks.
+func TestFindByIDsChunked(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var ids []primitive.ObjectID
+
+	for range 7 {
+		ids = append(ids, testInsertOne(ctx, t, m, john))
+	}
+
+	testInsertOne(ctx, t, m, mary)
+
+	records, errFind := m.FindByIDsChunked(ctx, append(ids, primitive.NewObjectID()), 3, 2)
+	require.NoError(t, errFind)
+	require.Len(t, records, len(ids))
+
+	for _, found := range records {
+		assert.Contains(t, ids, found["_id"])
+	}
+
+	_, errChunk := m.FindByIDsChunked(ctx, ids, 0, 2)
+	require.Error(t, errChunk)
+}
+
+// TestUseCollection Should write to other collections through the shared connection.
+func TestUseCollection(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	orders := m.UseCollection("orders")
+
+	_, errInsert := orders.InsertOne(ctx, []byte(`{"Customer":"john"}`))
+	require.NoError(t, errInsert)
+
+	countOrders, errOrders := orders.Count(ctx, nil)
+	require.NoError(t, errOrders)
+	assert.EqualValues(t, 1, countOrders)
+
+	countPersons, errPersons := m.Count(ctx, nil)
+	require.NoError(t, errPersons)
+	assert.Zero(t, countPersons)
+}
+
+// TestUseDatabase Should write to another database through the shared connection.
+func TestUseDatabase(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	archive := m.UseDatabase("archive")
+
+	testInsertOne(ctx, t, archive, john)
+
+	countArchive, errArchive := archive.Count(ctx, nil)
+	require.NoError(t, errArchive)
+	assert.EqualValues(t, 1, countArchive)
+
+	countTesting, errTesting := m.Count(ctx, nil)
+	require.NoError(t, errTesting)
+	assert.Zero(t, countTesting)
+}
+
+// TestAutoReconnect Should run operations after the client got disconnected.
+func TestAutoReconnect(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	m.AutoReconnect = true
+
+	testInsertOne(ctx, t, m, john)
+
+	require.NoError(t, m.Disconnect(ctx))
+
+	count, errCount := m.Count(ctx, nil)
+	require.NoError(t, errCount)
+	assert.EqualValues(t, 1, count)
+}
+
+// TestInsertMany Should report the records written on partial success.
+func TestInsertMany(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	data := [][]byte{
+		[]byte(`{"_id":"a","Name":"john"}`),
+		[]byte(`{"_id":"a","Name":"mary"}`),
+		[]byte(`{"_id":"b","Name":"eve"}`),
+	}
+
+	ids, countUnordered, errUnordered := m.InsertMany(ctx, data, false)
+	require.Error(t, errUnordered)
+	assert.Equal(t, 2, countUnordered)
+	assert.Equal(t, []any{"a", "b"}, ids)
+
+	_, errDelete := m.DeleteAll(ctx, []byte(`{}`))
+	require.NoError(t, errDelete)
+
+	idsOrdered, countOrdered, errOrdered := m.InsertMany(ctx, data, true)
+	require.Error(t, errOrdered)
+	assert.Equal(t, 1, countOrdered)
+	assert.Equal(t, []any{"a"}, idsOrdered)
+}
+
+// TestDateFields Should store configured fields as dates and match them in JSON range filters.
+func TestDateFields(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	m.DateFields = []string{"Created"}
+
+	_, errInsert := m.InsertOne(ctx, []byte(`{"Name":"john","Created":"2020-01-02T03:04:05Z"}`))
+	require.NoError(t, errInsert)
+
+	_, errInsertLater := m.InsertOne(ctx, []byte(`{"Name":"mary","Created":"2021-01-02T03:04:05Z"}`))
+	require.NoError(t, errInsertLater)
+
+	records, errFind := m.FindManyFilterJSON(ctx, []byte(`{"Created":{"$lt":"2021-01-01T00:00:00Z"}}`))
+	require.NoError(t, errFind)
+	require.Len(t, records, 1)
+	assert.Equal(t, "john", records[0]["Name"])
+	assert.IsType(t, primitive.DateTime(0), records[0]["Created"])
+}
+
+// TestUseWriteConcern Should write with the overridden write concern.
+func TestUseWriteConcern(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	journaled := m.UseWriteConcern(
+		&mongoclient.WriteConcern{
+			W:        1,
+			Journal:  true,
+			WTimeout: time.Second,
+		},
+	)
+
+	testInsertOne(ctx, t, journaled, john)
+
+	count, errCount := m.Count(ctx, nil)
+	require.NoError(t, errCount)
+	assert.EqualValues(t, 1, count)
+}
@@ -0,0 +1,234 @@
+package mongoclient
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+// ReadOption configures a single read call, ex. where the read is routed to.
+// Without options reads use the client read preference which defaults to primary.
+type ReadOption func(*readOptions)
+
+type readOptions struct {
+	collation   *options.Collation
+	sort        bson.D
+	projection  bson.M
+	mode        readpref.Mode
+	prefOptions []readpref.Option
+}
+
+func newReadOptions(opts ...ReadOption) readOptions {
+	var result readOptions
+
+	for _, opt := range opts {
+		opt(&result)
+	}
+
+	return result
+}
+
+// WithMaxStaleness Option routes the read only to secondaries lagging behind the primary at most passed duration.
+// Server requires the duration to be at least 90 seconds.
+func WithMaxStaleness(d time.Duration) ReadOption {
+	return func(o *readOptions) {
+		o.mode = readpref.SecondaryMode
+		o.prefOptions = append(o.prefOptions, readpref.WithMaxStaleness(d))
+	}
+}
+
+// WithReadTags Option routes the read to members with the tags of passed sets, ex. map[string]string{"region": "eu"}.
+// Sets are tried in passed order, the first matching any member is used.
+// Unless another option chose the members the read goes to the nearest one, primary or secondary.
+func WithReadTags(tags ...map[string]string) ReadOption {
+	return func(o *readOptions) {
+		if o.mode == 0 {
+			o.mode = readpref.NearestMode
+		}
+
+		o.prefOptions = append(o.prefOptions, readpref.WithTagSets(tag.NewTagSetsFromMaps(tags)...))
+	}
+}
+
+// withMode routes the read to members of passed mode.
+func withMode(mode readpref.Mode) ReadOption {
+	return func(o *readOptions) {
+		o.mode = mode
+	}
+}
+
+// WithCollation Option runs the read with passed collation.
+// To use an index created with a collation, ex. by EnsureCaseInsensitiveIndex, the read collation must match it.
+func WithCollation(collation *options.Collation) ReadOption {
+	return func(o *readOptions) {
+		o.collation = collation
+	}
+}
+
+// WithSort Option returns the records in passed order, ex. bson.D{{Key: "Age", Value: -1}}.
+// Sort is a bson.D as the order of the keys matters for compound sorts.
+// An empty sort keeps the default order, which is not guaranteed.
+func WithSort(sort bson.D) ReadOption {
+	return func(o *readOptions) {
+		o.sort = sort
+	}
+}
+
+// WithProjection Option returns only the fields of passed projection, ex. bson.M{"Name": 1, "_id": 0}.
+// An empty projection returns whole records.
+func WithProjection(projection bson.M) ReadOption {
+	return func(o *readOptions) {
+		o.projection = projection
+	}
+}
+
+// readCollection returns configured collection with the read preference built from passed options.
+func (m *Client) readCollection(opts ...ReadOption) (*mongo.Collection, error) {
+	if len(opts) == 0 {
+		return m.driver().Database(m.Database).Collection(m.Collection),
+			nil
+	}
+
+	pref, errPref := m.readPreference(opts...)
+	if errPref != nil {
+		return nil,
+			errPref
+	}
+
+	return m.driver().
+			Database(m.Database).
+			Collection(
+				m.Collection,
+				options.Collection().SetReadPreference(pref),
+			),
+		nil
+}
+
+// readPreference returns the read preference built from passed options, primary unless they route elsewhere.
+// With HedgedReads non primary reads are hedged.
+func (m *Client) readPreference(opts ...ReadOption) (*readpref.ReadPref, error) {
+	config := newReadOptions(opts...)
+	if config.mode == 0 {
+		config.mode = readpref.PrimaryMode
+	}
+
+	if m.HedgedReads && config.mode != readpref.PrimaryMode {
+		config.prefOptions = append(config.prefOptions, readpref.WithHedgeEnabled(true))
+	}
+
+	pref, errPref := readpref.New(config.mode, config.prefOptions...)
+	if errPref != nil {
+		return nil,
+			errors.Wrap(errPref, "invalid read preference")
+	}
+
+	return pref,
+		nil
+}
+
+// newFindOptions returns the find options built from passed read options.
+func newFindOptions(opts ...ReadOption) *options.FindOptions {
+	result := options.Find()
+	config := newReadOptions(opts...)
+
+	if config.collation != nil {
+		result.SetCollation(config.collation)
+	}
+
+	if len(config.sort) > 0 {
+		result.SetSort(config.sort)
+	}
+
+	if len(config.projection) > 0 {
+		result.SetProjection(config.projection)
+	}
+
+	return result
+}
+
+// newFindOneOptions returns the find one options built from passed read options.
+func newFindOneOptions(opts ...ReadOption) *options.FindOneOptions {
+	result := options.FindOne()
+	config := newReadOptions(opts...)
+
+	if config.collation != nil {
+		result.SetCollation(config.collation)
+	}
+
+	if len(config.sort) > 0 {
+		result.SetSort(config.sort)
+	}
+
+	if len(config.projection) > 0 {
+		result.SetProjection(config.projection)
+	}
+
+	return result
+}
+
+// UpdateOption configures a single update call.
+type UpdateOption func(*options.UpdateOptions)
+
+// WithUpsert Option inserts a record built from the filter and the update when no record matches.
+// The update result then reports UpsertedCount 1 and the UpsertedID of the new record.
+func WithUpsert() UpdateOption {
+	return func(o *options.UpdateOptions) {
+		o.SetUpsert(true)
+	}
+}
+
+func newUpdateOptions(opts ...UpdateOption) *options.UpdateOptions {
+	result := options.Update()
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	return result
+}
+
+// AggregateOption configures a single aggregate call.
+type AggregateOption func(*options.AggregateOptions)
+
+// WithAllowDiskUse Option lets the pipeline stages write temporary files when they exceed the memory limit,
+// ex. large $group or $sort stages.
+func WithAllowDiskUse() AggregateOption {
+	return func(o *options.AggregateOptions) {
+		o.SetAllowDiskUse(true)
+	}
+}
+
+func newAggregateOptions(opts ...AggregateOption) *options.AggregateOptions {
+	result := options.Aggregate()
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	return result
+}
+
+// FindAndUpdateOption configures a single find and update call.
+type FindAndUpdateOption func(*options.FindOneAndUpdateOptions)
+
+// WithReturnBefore Option returns the record as it was before the update instead of after it.
+func WithReturnBefore() FindAndUpdateOption {
+	return func(o *options.FindOneAndUpdateOptions) {
+		o.SetReturnDocument(options.Before)
+	}
+}
+
+func newFindAndUpdateOptions(opts ...FindAndUpdateOption) *options.FindOneAndUpdateOptions {
+	result := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	return result
+}
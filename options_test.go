@@ -0,0 +1,106 @@
+package mongoclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestReadOptionsMaxStaleness(t *testing.T) {
+	var config readOptions
+
+	WithMaxStaleness(2 * time.Minute)(&config)
+
+	pref, errPref := readpref.New(config.mode, config.prefOptions...)
+	require.NoError(t, errPref)
+	assert.Equal(t, readpref.SecondaryMode, pref.Mode())
+
+	staleness, isSet := pref.MaxStaleness()
+	assert.True(t, isSet)
+	assert.Equal(t, 2*time.Minute, staleness)
+}
+
+func TestReadOptionsTags(t *testing.T) {
+	var config readOptions
+
+	WithReadTags(map[string]string{"region": "eu"}, map[string]string{})(&config)
+
+	pref, errPref := readpref.New(config.mode, config.prefOptions...)
+	require.NoError(t, errPref)
+	assert.Equal(t, readpref.NearestMode, pref.Mode())
+	require.Len(t, pref.TagSets(), 2)
+	assert.True(t, pref.TagSets()[0].Contains("region", "eu"))
+	assert.Empty(t, pref.TagSets()[1])
+
+	var secondary readOptions
+
+	WithMaxStaleness(2 * time.Minute)(&secondary)
+	WithReadTags(map[string]string{"region": "eu"})(&secondary)
+
+	assert.Equal(t, readpref.SecondaryMode, secondary.mode)
+}
+
+func TestReadPreferenceHedgedReads(t *testing.T) {
+	m := &Client{
+		Cfg: &Cfg{
+			HedgedReads: true,
+		},
+	}
+
+	stale, errStale := m.readPreference(WithMaxStaleness(2 * time.Minute))
+	require.NoError(t, errStale)
+	require.NotNil(t, stale.HedgeEnabled())
+	assert.True(t, *stale.HedgeEnabled())
+
+	secondaryPreferred, errSecondaryPreferred := m.readPreference(withMode(readpref.SecondaryPreferredMode))
+	require.NoError(t, errSecondaryPreferred)
+	require.NotNil(t, secondaryPreferred.HedgeEnabled())
+	assert.True(t, *secondaryPreferred.HedgeEnabled())
+
+	primary, errPrimary := m.readPreference(WithProjection(bson.M{"Name": 1}))
+	require.NoError(t, errPrimary)
+	assert.Equal(t, readpref.PrimaryMode, primary.Mode())
+	assert.Nil(t, primary.HedgeEnabled())
+
+	m.HedgedReads = false
+
+	notHedged, errNotHedged := m.readPreference(WithMaxStaleness(2 * time.Minute))
+	require.NoError(t, errNotHedged)
+	assert.Nil(t, notHedged.HedgeEnabled())
+}
+
+func TestFindOptionsProjection(t *testing.T) {
+	require.Nil(t, newFindOptions(WithProjection(bson.M{})).Projection)
+	require.Nil(t, newFindOneOptions(WithProjection(nil)).Projection)
+
+	projection := bson.M{"Name": 1, "_id": 0}
+	assert.Equal(t, projection, newFindOptions(WithProjection(projection)).Projection)
+	assert.Equal(t, projection, newFindOneOptions(WithProjection(projection)).Projection)
+}
+
+func TestAggregateOptionsAllowDiskUse(t *testing.T) {
+	require.Nil(t, newAggregateOptions().AllowDiskUse)
+
+	allow := newAggregateOptions(WithAllowDiskUse()).AllowDiskUse
+	require.NotNil(t, allow)
+	assert.True(t, *allow)
+}
+
+func TestFindAndUpdateOptionsReturnDocument(t *testing.T) {
+	assert.Equal(t, options.After, *newFindAndUpdateOptions().ReturnDocument)
+	assert.Equal(t, options.Before, *newFindAndUpdateOptions(WithReturnBefore()).ReturnDocument)
+}
+
+func TestWatchOptionsFullDocumentBeforeChange(t *testing.T) {
+	streamOptions := options.ChangeStream()
+
+	WithFullDocumentBeforeChange()(streamOptions)
+
+	require.NotNil(t, streamOptions.FullDocumentBeforeChange)
+	assert.Equal(t, options.WhenAvailable, *streamOptions.FullDocumentBeforeChange)
+}
@@ -0,0 +1,31 @@
+package mongoclient
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UpdateOutcome is the result of the update methods, independent of the driver result type.
+type UpdateOutcome struct {
+	// Matched is the number of records matching the filter.
+	Matched int64
+	// Modified is the number of matching records the update changed.
+	Modified int64
+	// Upserted is the number of records inserted as none matched, with WithUpsert.
+	Upserted int64
+	// UpsertedID is the ID of the inserted record, nil when none was.
+	UpsertedID any
+}
+
+// newUpdateOutcome converts passed driver result, nil when there is none, ex. on errors.
+func newUpdateOutcome(result *mongo.UpdateResult) *UpdateOutcome {
+	if result == nil {
+		return nil
+	}
+
+	return &UpdateOutcome{
+		Matched:    result.MatchedCount,
+		Modified:   result.ModifiedCount,
+		Upserted:   result.UpsertedCount,
+		UpsertedID: result.UpsertedID,
+	}
+}
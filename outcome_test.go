@@ -0,0 +1,25 @@
+package mongoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestNewUpdateOutcome(t *testing.T) {
+	assert.Nil(t, newUpdateOutcome(nil))
+
+	assert.Equal(t,
+		&UpdateOutcome{
+			Matched:    0,
+			Modified:   0,
+			Upserted:   1,
+			UpsertedID: "jane",
+		},
+		newUpdateOutcome(&mongo.UpdateResult{
+			UpsertedCount: 1,
+			UpsertedID:    "jane",
+		}),
+	)
+}
@@ -0,0 +1,72 @@
+package mongoclient
+
+import (
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// PoolStats is a point in time view of the connection pool, summed over all the servers the client uses.
+type PoolStats struct {
+	// CheckedOut is the number of connections currently used by operations.
+	CheckedOut int64
+	// Available is the number of open connections idle in the pool.
+	Available int64
+	// WaitQueue is the number of operations waiting to check out a connection.
+	WaitQueue int64
+}
+
+// poolCounters keeps the pool counters updated by the driver pool events.
+type poolCounters struct {
+	open       atomic.Int64
+	checkedOut atomic.Int64
+	waiting    atomic.Int64
+}
+
+func (p *poolCounters) monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				p.open.Add(1)
+
+			case event.ConnectionClosed:
+				p.open.Add(-1)
+
+			case event.GetStarted:
+				p.waiting.Add(1)
+
+			case event.GetSucceeded:
+				p.waiting.Add(-1)
+				p.checkedOut.Add(1)
+
+			case event.GetFailed:
+				p.waiting.Add(-1)
+
+			case event.ConnectionReturned:
+				p.checkedOut.Add(-1)
+			}
+		},
+	}
+}
+
+func (p *poolCounters) stats() PoolStats {
+	checkedOut := p.checkedOut.Load()
+
+	return PoolStats{
+		CheckedOut: checkedOut,
+		Available:  max(p.open.Load()-checkedOut, 0),
+		WaitQueue:  p.waiting.Load(),
+	}
+}
+
+// PoolStats Method returns the current connection pool usage, ex. for spotting pool saturation.
+func (m *Client) PoolStats() PoolStats {
+	return m.conn.pool.stats()
+}
+
+// InFlight Method returns the number of operations currently run by the client and the clients sharing its
+// connection, ex. for checking how close they are to MaxConcurrentOps.
+func (m *Client) InFlight() int64 {
+	return m.conn.inFlight.Load()
+}
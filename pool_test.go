@@ -0,0 +1,47 @@
+package mongoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestPoolCounters(t *testing.T) {
+	var pool poolCounters
+
+	monitor := pool.monitor()
+
+	for _, eventType := range []string{
+		event.ConnectionCreated,
+		event.ConnectionCreated,
+		event.GetStarted,
+		event.GetSucceeded,
+		event.GetStarted,
+		event.GetStarted,
+		event.GetFailed,
+	} {
+		monitor.Event(&event.PoolEvent{Type: eventType})
+	}
+
+	require.Equal(t,
+		PoolStats{
+			CheckedOut: 1,
+			Available:  1,
+			WaitQueue:  1,
+		},
+		pool.stats(),
+	)
+
+	monitor.Event(&event.PoolEvent{Type: event.ConnectionReturned})
+	monitor.Event(&event.PoolEvent{Type: event.ConnectionClosed})
+
+	require.Equal(t,
+		PoolStats{
+			CheckedOut: 0,
+			Available:  1,
+			WaitQueue:  1,
+		},
+		pool.stats(),
+	)
+}
@@ -0,0 +1,25 @@
+package mongoclient
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindOneProjected Method finds one record based on passed filter returning only the projected fields.
+// Projecting out the ID is done with "_id": 0. An empty projection returns the whole record.
+func (m *Client) FindOneProjected(ctx context.Context, filter []byte, projection bson.M, opts ...ReadOption) (bson.M, error) {
+	result, errFind := m.FindOne(ctx, filter, append(opts, WithProjection(projection))...)
+	if errFind != nil {
+		return nil,
+			errFind
+	}
+
+	return result.(bson.M),
+		nil
+}
+
+// FindManyProjected Method finds the records based on passed filter returning only the projected fields.
+func (m *Client) FindManyProjected(ctx context.Context, filter []byte, projection bson.M, opts ...ReadOption) ([]bson.M, error) {
+	return m.FindManyFilterJSON(ctx, filter, append(opts, WithProjection(projection))...)
+}
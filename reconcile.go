@@ -0,0 +1,244 @@
+package mongoclient
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ReconcileMany Method makes the configured collection contain passed JSON records, matched by keyField.
+// Records without a stored counterpart are inserted, records differing from the stored one replace it and
+// identical records are skipped, so that unchanged data causes no writes.
+// Comparison ignores the stored _id when the passed record has none and number types, ie. 1 equals 1.0.
+func (m *Client) ReconcileMany(ctx context.Context, keyField string, docs [][]byte) (inserted, updated, unchanged int64, err error) {
+	defer m.logSlow("ReconcileMany", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	records := make([]bson.M, len(docs))
+	keys := make([]any, len(docs))
+	positions := make(map[string]int, len(docs))
+
+	for i, doc := range docs {
+		record, errConv := jsonToBsonM(doc)
+		if errConv != nil {
+			return 0, 0, 0,
+				errConv
+		}
+
+		convertDates(record, m.DateFields)
+
+		if errSize := checkDocumentSize(record); errSize != nil {
+			return 0, 0, 0,
+				errSize
+		}
+
+		key, hasKey := record[keyField]
+		if !hasKey {
+			return 0, 0, 0,
+				errors.Errorf("record %d has no %s field", i, keyField)
+		}
+
+		canonicalKey, errKey := canonicalBSON(key)
+		if errKey != nil {
+			return 0, 0, 0,
+				errKey
+		}
+
+		if _, isDuplicate := positions[string(canonicalKey)]; isDuplicate {
+			return 0, 0, 0,
+				errors.Errorf("records have duplicate %s value %v", keyField, key)
+		}
+
+		positions[string(canonicalKey)] = i
+		records[i] = record
+		keys[i] = key
+	}
+
+	collection := m.writeCollection()
+
+	cursor, errFind := collection.Find(ctxLocal, bson.M{keyField: bson.M{"$in": keys}})
+	if errFind != nil {
+		return 0, 0, 0,
+			errFind
+	}
+	defer cursor.Close(ctxLocal)
+
+	existing, errWalk := m.walkMongoSet(ctxLocal, cursor)
+	if errWalk != nil {
+		return 0, 0, 0,
+			errWalk
+	}
+
+	stored := make(map[int]bson.M, len(existing))
+
+	for _, record := range existing {
+		canonicalKey, errKey := canonicalBSON(record[keyField])
+		if errKey != nil {
+			return 0, 0, 0,
+				errKey
+		}
+
+		if position, isPassed := positions[string(canonicalKey)]; isPassed {
+			stored[position] = record
+		}
+	}
+
+	var models []mongo.WriteModel
+
+	for i, record := range records {
+		current, isStored := stored[i]
+		if !isStored {
+			if _, hasID := record["_id"]; !hasID && m.IDGenerator != nil {
+				record["_id"] = m.IDGenerator()
+			}
+
+			models = append(models, mongo.NewInsertOneModel().SetDocument(record))
+			inserted++
+
+			continue
+		}
+
+		isEqual, errCompare := sameRecord(record, current)
+		if errCompare != nil {
+			return 0, 0, 0,
+				errCompare
+		}
+
+		if isEqual {
+			unchanged++
+
+			continue
+		}
+
+		models = append(models,
+			mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": current["_id"]}).
+				SetReplacement(record),
+		)
+		updated++
+	}
+
+	if len(models) == 0 {
+		return inserted, updated, unchanged,
+			nil
+	}
+
+	if _, errWrite := collection.BulkWrite(ctxLocal, models); errWrite != nil {
+		return 0, 0, 0,
+			writeConcernTimeout(errWrite)
+	}
+
+	return inserted, updated, unchanged,
+		nil
+}
+
+// sameRecord compares passed record with the stored one.
+func sameRecord(record, stored bson.M) (bool, error) {
+	if _, hasID := record["_id"]; !hasID {
+		withoutID := make(bson.M, len(stored))
+
+		for key, value := range stored {
+			if key != "_id" {
+				withoutID[key] = value
+			}
+		}
+
+		stored = withoutID
+	}
+
+	canonicalRecord, errRecord := canonicalBSON(record)
+	if errRecord != nil {
+		return false, errRecord
+	}
+
+	canonicalStored, errStored := canonicalBSON(stored)
+	if errStored != nil {
+		return false, errStored
+	}
+
+	return bytes.Equal(canonicalRecord, canonicalStored),
+		nil
+}
+
+// canonicalBSON marshals passed value with sorted keys and numbers as doubles so equal values have equal bytes.
+func canonicalBSON(value any) ([]byte, error) {
+	raw, errMarshal := bson.Marshal(bson.D{{Key: "v", Value: normalize(value)}})
+	if errMarshal != nil {
+		return nil,
+			errors.Wrap(errMarshal, "could not marshal value")
+	}
+
+	return raw,
+		nil
+}
+
+func normalize(value any) any {
+	switch typed := value.(type) {
+	case bson.M:
+		return normalizeMap(typed)
+
+	case map[string]any:
+		return normalizeMap(typed)
+
+	case bson.D:
+		result := make(bson.M, len(typed))
+
+		for _, element := range typed {
+			result[element.Key] = element.Value
+		}
+
+		return normalizeMap(result)
+
+	case bson.A:
+		return normalizeSlice(typed)
+
+	case []any:
+		return normalizeSlice(typed)
+
+	case int:
+		return float64(typed)
+
+	case int32:
+		return float64(typed)
+
+	case int64:
+		return float64(typed)
+	}
+
+	return value
+}
+
+func normalizeMap(value map[string]any) bson.D {
+	keys := make([]string, 0, len(value))
+
+	for key := range value {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	result := make(bson.D, len(keys))
+
+	for i, key := range keys {
+		result[i] = bson.E{Key: key, Value: normalize(value[key])}
+	}
+
+	return result
+}
+
+func normalizeSlice(value []any) bson.A {
+	result := make(bson.A, len(value))
+
+	for i, item := range value {
+		result[i] = normalize(item)
+	}
+
+	return result
+}
@@ -0,0 +1,37 @@
+package mongoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSameRecord(t *testing.T) {
+	stored := bson.M{
+		"_id":     primitive.NewObjectID(),
+		"Name":    "mary",
+		"Age":     int32(44),
+		"Address": bson.M{"City": "Paris", "Zip": "75001"},
+	}
+
+	isEqual, errCompare := sameRecord(
+		bson.M{
+			"Name":    "mary",
+			"Age":     float64(44),
+			"Address": map[string]any{"Zip": "75001", "City": "Paris"},
+		},
+		stored,
+	)
+	require.NoError(t, errCompare)
+	assert.True(t, isEqual)
+
+	isEqual, errCompare = sameRecord(
+		bson.M{"Name": "mary", "Age": float64(45), "Address": bson.M{"City": "Paris", "Zip": "75001"}},
+		stored,
+	)
+	require.NoError(t, errCompare)
+	assert.False(t, isEqual)
+}
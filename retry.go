@@ -0,0 +1,54 @@
+package mongoclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// retryBackoff is the wait before the first retry, doubled for each next one.
+const retryBackoff = 100 * time.Millisecond
+
+// retryLabels are the labels of the driver errors worth retrying.
+var retryLabels = []string{"RetryableWriteError", "NetworkError"}
+
+// retryWrite runs passed write and retries it while it fails with a retryable error,
+// at most up to configured WriteAttempts. Waits between attempts end when passed context is done.
+func (m *Client) retryWrite(ctx context.Context, write func() error) error {
+	errWrite := write()
+
+	for attempt := uint(1); attempt < m.WriteAttempts && isRetryable(errWrite); attempt++ {
+		wait := retryBackoff << (attempt - 1)
+
+		m.logger().Warnf("retrying write on %s.%s in %s: %s", m.Database, m.Collection, wait, errWrite)
+
+		select {
+		case <-ctx.Done():
+			return errWrite
+
+		case <-time.After(wait):
+		}
+
+		errWrite = write()
+	}
+
+	return errWrite
+}
+
+// isRetryable returns whether passed driver error carries a label of a transient failure.
+func isRetryable(err error) bool {
+	var errLabeled mongo.LabeledError
+	if !errors.As(err, &errLabeled) {
+		return false
+	}
+
+	for _, label := range retryLabels {
+		if errLabeled.HasErrorLabel(label) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+package mongoclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestRetryWrite(t *testing.T) {
+	errTransient := mongo.CommandError{Code: 189, Labels: []string{"RetryableWriteError"}}
+	errPermanent := mongo.CommandError{Code: 2}
+
+	tests := []struct {
+		name         string
+		attempts     uint
+		failures     []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{name: "success", attempts: 3, wantAttempts: 1},
+		{name: "retried until success", attempts: 3, failures: []error{errTransient, errTransient}, wantAttempts: 3},
+		{name: "attempts exhausted", attempts: 2, failures: []error{errTransient, errTransient}, wantErr: errTransient, wantAttempts: 2},
+		{name: "not retryable", attempts: 3, failures: []error{errPermanent}, wantErr: errPermanent, wantAttempts: 1},
+		{name: "retries disabled", failures: []error{errTransient}, wantErr: errTransient, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Client{
+				Cfg: &Cfg{
+					WriteAttempts: tt.attempts,
+				},
+			}
+
+			var attempts int
+
+			errWrite := m.retryWrite(context.Background(), func() error {
+				attempts++
+
+				if attempts <= len(tt.failures) {
+					return tt.failures[attempts-1]
+				}
+
+				return nil
+			})
+
+			assert.Equal(t, tt.wantErr, errWrite)
+			assert.Equal(t, tt.wantAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryWriteContextDone(t *testing.T) {
+	m := &Client{
+		Cfg: &Cfg{
+			WriteAttempts: 10,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var attempts int
+
+	started := time.Now()
+
+	errWrite := m.retryWrite(ctx, func() error {
+		attempts++
+
+		return mongo.CommandError{Labels: []string{"NetworkError"}}
+	})
+	require.Error(t, errWrite)
+	assert.Equal(t, 1, attempts)
+	assert.Less(t, time.Since(started), retryBackoff)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, isRetryable(mongo.CommandError{Labels: []string{"RetryableWriteError"}}))
+	assert.True(t, isRetryable(mongo.WriteException{Labels: []string{"NetworkError"}}))
+	assert.False(t, isRetryable(mongo.CommandError{Code: 11000}))
+	assert.False(t, isRetryable(errors.New("other")))
+	assert.False(t, isRetryable(nil))
+}
+
+func TestInsertRetriedKeepsID(t *testing.T) {
+	m := &Client{
+		Cfg: &Cfg{
+			WriteAttempts: 3,
+		},
+	}
+
+	var ids []any
+
+	id, errInsert := m.insertRetried(context.Background(), bson.M{"Name": "mary"}, func(document bson.M) (*mongo.InsertOneResult, error) {
+		ids = append(ids, document["_id"])
+
+		if len(ids) < 3 {
+			return nil, mongo.CommandError{Labels: []string{"NetworkError"}}
+		}
+
+		return &mongo.InsertOneResult{InsertedID: document["_id"]}, nil
+	})
+	require.NoError(t, errInsert)
+	require.Len(t, ids, 3)
+
+	assert.IsType(t, primitive.ObjectID{}, id)
+	assert.Equal(t, []any{id, id, id}, ids)
+}
@@ -0,0 +1,100 @@
+package mongoclient
+
+import (
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// defaultDenyOperators are operators executing server side JavaScript.
+var defaultDenyOperators = []string{"$where", "$function", "$accumulator"}
+
+// filterFromJSON converts passed JSON filter and rejects it if it uses a denied operator.
+func (m *Client) filterFromJSON(filterJSON []byte) (bson.M, error) {
+	result, errConv := jsonToBsonM(filterJSON)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	deny := m.DenyOperators
+	if deny == nil {
+		deny = defaultDenyOperators
+	}
+
+	if errCheck := checkOperators(result, deny); errCheck != nil {
+		return nil, errCheck
+	}
+
+	convertDates(result, m.DateFields)
+
+	m.logFilter(result)
+
+	return result,
+		nil
+}
+
+// filterFromJSONOrAll converts passed JSON filter as filterFromJSON, an empty filter matches all records.
+func (m *Client) filterFromJSONOrAll(filterJSON []byte) (bson.M, error) {
+	if len(filterJSON) == 0 {
+		return bson.M{},
+			nil
+	}
+
+	return m.filterFromJSON(filterJSON)
+}
+
+// checkOperators walks passed value looking for keys in the deny list.
+func checkOperators(value any, deny []string) error {
+	switch typed := value.(type) {
+	case bson.M:
+		return checkOperatorsMap(typed, deny)
+
+	case map[string]any:
+		return checkOperatorsMap(typed, deny)
+
+	case bson.D:
+		for _, element := range typed {
+			if errCheck := checkOperator(element.Key, element.Value, deny); errCheck != nil {
+				return errCheck
+			}
+		}
+
+	case bson.A:
+		return checkOperatorsSlice(typed, deny)
+
+	case []any:
+		return checkOperatorsSlice(typed, deny)
+	}
+
+	return nil
+}
+
+func checkOperatorsSlice(value []any, deny []string) error {
+	for _, item := range value {
+		if errCheck := checkOperators(item, deny); errCheck != nil {
+			return errCheck
+		}
+	}
+
+	return nil
+}
+
+func checkOperatorsMap(value map[string]any, deny []string) error {
+	for key, item := range value {
+		if errCheck := checkOperator(key, item, deny); errCheck != nil {
+			return errCheck
+		}
+	}
+
+	return nil
+}
+
+// checkOperator checks passed key against the deny list, then walks its value.
+func checkOperator(key string, value any, deny []string) error {
+	for _, operator := range deny {
+		if key == operator {
+			return errors.Wrapf(ErrForbiddenOperator, "operator %s", key)
+		}
+	}
+
+	return checkOperators(value, deny)
+}
@@ -0,0 +1,59 @@
+package mongoclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFilterFromJSON(t *testing.T) {
+	m := &Client{
+		Cfg: &Cfg{},
+	}
+
+	filter, errFilter := m.filterFromJSON([]byte(`{"Age":{"$gt":40}}`))
+	require.NoError(t, errFilter)
+	assert.NotEmpty(t, filter)
+
+	_, errWhere := m.filterFromJSON([]byte(`{"$where":"sleep(1000)"}`))
+	assert.True(t, errors.Is(errWhere, ErrForbiddenOperator))
+
+	_, errNested := m.filterFromJSON([]byte(`{"$or":[{"Name":"mary"},{"$expr":{"$function":{}}}]}`))
+	assert.True(t, errors.Is(errNested, ErrForbiddenOperator))
+
+	m.DenyOperators = []string{}
+
+	_, errAllowed := m.filterFromJSON([]byte(`{"$where":"true"}`))
+	assert.NoError(t, errAllowed)
+}
+
+func TestFilterFromJSONOrAll(t *testing.T) {
+	m := &Client{
+		Cfg: &Cfg{},
+	}
+
+	all, errAll := m.filterFromJSONOrAll(nil)
+	require.NoError(t, errAll)
+	assert.Empty(t, all)
+	assert.NotNil(t, all)
+
+	_, errWhere := m.filterFromJSONOrAll([]byte(`{"$where":"true"}`))
+	assert.True(t, errors.Is(errWhere, ErrForbiddenOperator))
+}
+
+func TestCheckOperatorsBSON(t *testing.T) {
+	deny := []string{"$where"}
+
+	require.NoError(t,
+		checkOperators(bson.M{"$and": bson.A{bson.D{{Key: "Name", Value: "mary"}}}}, deny),
+	)
+
+	errNested := checkOperators(
+		bson.M{"$and": bson.A{bson.M{"Name": "mary"}, bson.D{{Key: "$where", Value: "true"}}}},
+		deny,
+	)
+	assert.True(t, errors.Is(errNested, ErrForbiddenOperator))
+}
@@ -0,0 +1,58 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SearchFiltered Method runs a full text search for passed term restricted to the records matching passed filter.
+// Results are sorted by relevance, the text score is returned in the score field.
+// A nil filter searches all records, a non positive limit returns all matches.
+// Requires a text index on the configured collection.
+func (m *Client) SearchFiltered(ctx context.Context, term string, filter []byte, limit int64) ([]bson.M, error) {
+	defer m.logSlow("SearchFiltered", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonFilter, errConv := m.filterFromJSONOrAll(filter)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	search := bson.M{
+		"$text": bson.M{"$search": term},
+	}
+
+	if len(bsonFilter) > 0 {
+		search = bson.M{
+			"$and": bson.A{search, bsonFilter},
+		}
+	}
+
+	score := bson.M{"$meta": "textScore"}
+
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.M{"score": score})
+
+	if limit > 0 {
+		findOptions.SetLimit(limit)
+	}
+
+	cursor, errFind := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Find(ctxLocal, search, findOptions)
+	if errFind != nil {
+		return nil,
+			errFind
+	}
+	defer cursor.Close(ctxLocal)
+
+	return m.walkMongoSet(ctxLocal, cursor)
+}
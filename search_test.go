@@ -0,0 +1,53 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"mongoclient/testutil"
+)
+
+func TestSearchFiltered(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, errIndex := m.EnsureIndex(ctx, bson.D{{Key: "Name", Value: "text"}}, nil)
+	require.NoError(t, errIndex)
+
+	for _, data := range []string{
+		`{"Name":"mary ann lee","Gender":"female"}`,
+		`{"Name":"mary mary","Gender":"female"}`,
+		`{"Name":"mary","Gender":"male"}`,
+		`{"Name":"john","Gender":"male"}`,
+	} {
+		_, errInsert := m.InsertOne(ctx, []byte(data))
+		require.NoError(t, errInsert)
+	}
+
+	records, errSearch := m.SearchFiltered(ctx, "mary", []byte(`{"Gender":"female"}`), 0)
+	require.NoError(t, errSearch)
+	require.Len(t, records, 2)
+
+	for _, record := range records {
+		assert.Equal(t, "female", record["Gender"])
+	}
+
+	all, errAll := m.SearchFiltered(ctx, "mary", nil, 0)
+	require.NoError(t, errAll)
+	require.Len(t, all, 3)
+
+	for i := 1; i < len(all); i++ {
+		assert.GreaterOrEqual(t, all[i-1]["score"], all[i]["score"])
+	}
+
+	limited, errLimited := m.SearchFiltered(ctx, "mary", nil, 1)
+	require.NoError(t, errLimited)
+	require.Len(t, limited, 1)
+	assert.Equal(t, all[0]["score"], limited[0]["score"])
+}
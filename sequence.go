@@ -0,0 +1,55 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sequenceCollection holds one counter record per sequence name, in the configured database.
+const sequenceCollection = "counters"
+
+// NextSequence Method increments the sequence with passed name and returns its new value.
+// Sequence is created on first use, starting at 1.
+func (m *Client) NextSequence(ctx context.Context, name string) (int64, error) {
+	return m.ReserveSequence(ctx, name, 1)
+}
+
+// ReserveSequence Method advances the sequence with passed name by n in one atomic update and returns
+// the first number of the reserved range, start to start+n-1, so that callers could hand out IDs locally.
+// Sequence is created on first use, starting at 1.
+func (m *Client) ReserveSequence(ctx context.Context, name string, n int64) (int64, error) {
+	defer m.logSlow("ReserveSequence", time.Now())
+
+	if n < 1 {
+		return 0,
+			errors.Errorf("number of reserved values %d should be positive", n)
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	var result struct {
+		Seq int64 `bson:"seq"`
+	}
+
+	if errUpdate := m.writeCollectionNamed(sequenceCollection).
+		FindOneAndUpdate(
+			ctxLocal,
+			bson.M{"_id": name},
+			bson.M{"$inc": bson.M{"seq": n}},
+			options.FindOneAndUpdate().
+				SetUpsert(true).
+				SetReturnDocument(options.After),
+		).
+		Decode(&result); errUpdate != nil {
+		return 0,
+			writeConcernTimeout(errUpdate)
+	}
+
+	return result.Seq - n + 1,
+		nil
+}
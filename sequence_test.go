@@ -0,0 +1,47 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"mongoclient/testutil"
+)
+
+func TestNextSequence(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	first, errFirst := m.NextSequence(ctx, "invoices")
+	require.NoError(t, errFirst)
+	require.EqualValues(t, 1, first)
+
+	second, errSecond := m.NextSequence(ctx, "invoices")
+	require.NoError(t, errSecond)
+	require.EqualValues(t, 2, second)
+}
+
+func TestReserveSequence(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, errInvalid := m.ReserveSequence(ctx, "invoices", 0)
+	require.Error(t, errInvalid)
+
+	first, errFirst := m.ReserveSequence(ctx, "invoices", 100)
+	require.NoError(t, errFirst)
+	require.EqualValues(t, 1, first)
+
+	second, errSecond := m.ReserveSequence(ctx, "invoices", 50)
+	require.NoError(t, errSecond)
+	require.EqualValues(t, 101, second)
+
+	next, errNext := m.NextSequence(ctx, "invoices")
+	require.NoError(t, errNext)
+	require.EqualValues(t, 151, next)
+}
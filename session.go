@@ -0,0 +1,137 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// Session based operations require a replica set or a sharded cluster, they are not supported by standalone servers.
+
+// InsertThenRead Method inserts the data and reads it back within a causally consistent session.
+// The read observes the write even when the client reads from secondaries.
+// Write and read use majority concerns as causal consistency is only guaranteed with them.
+// Requires a replica set.
+func (m *Client) InsertThenRead(ctx context.Context, data []byte) (bson.M, error) {
+	defer m.logSlow("InsertThenRead", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	dataM, errConv := m.documentFromJSON(data)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	session, errSession := m.driver().StartSession(
+		options.Session().SetCausalConsistency(true),
+	)
+	if errSession != nil {
+		return nil,
+			errSession
+	}
+	defer session.EndSession(ctxLocal)
+
+	collection := m.driver().
+		Database(m.Database).
+		Collection(
+			m.Collection,
+			options.Collection().
+				SetWriteConcern(writeconcern.Majority()).
+				SetReadConcern(readconcern.Majority()),
+		)
+
+	var result bson.M
+
+	errWithSession := mongo.WithSession(ctxLocal, session, func(sessCtx mongo.SessionContext) error {
+		inserted, errInsert := collection.InsertOne(sessCtx, dataM)
+		if errInsert != nil {
+			return errInsert
+		}
+
+		return collection.
+			FindOne(sessCtx, bson.M{"_id": inserted.InsertedID}).
+			Decode(&result)
+	})
+	if errWithSession != nil {
+		return nil,
+			writeConcernTimeout(errWithSession)
+	}
+
+	return result,
+		nil
+}
+
+// SnapshotRead Method runs fn within a session reading with snapshot read concern, so all the reads fn does
+// with passed context see the same point in time view of the data, ex. for consistent multi query reports.
+// Reads should use the context passed to fn, reads using other contexts are outside of the snapshot.
+// Requires a replica set or sharded cluster running Mongo DB 5.0 or later, writes are not allowed in the session.
+// The snapshot is kept by the server only for a limited time, by default 5 minutes.
+func (m *Client) SnapshotRead(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, errSession := m.driver().StartSession(
+		options.Session().SetSnapshot(true),
+	)
+	if errSession != nil {
+		return errSession
+	}
+	defer session.EndSession(ctx)
+
+	return mongo.WithSession(ctx, session, func(sessCtx mongo.SessionContext) error {
+		return fn(sessCtx)
+	})
+}
+
+// WithTransaction Method runs fn within a transaction, committed when fn returns nil and aborted otherwise.
+// Operations take part in the transaction when called with the session context passed to fn, ex. the methods
+// of this client. Fn could be run more than once as transient errors are retried, so it should be idempotent
+// apart from the transaction writes. Requires a replica set or a sharded cluster.
+func (m *Client) WithTransaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error {
+	session, errSession := m.driver().StartSession()
+	if errSession != nil {
+		return errSession
+	}
+	defer session.EndSession(ctx)
+
+	_, errTransaction := session.WithTransaction(
+		ctx,
+		func(sessCtx mongo.SessionContext) (any, error) {
+			return nil, fn(sessCtx)
+		},
+		options.Transaction().
+			SetWriteConcern(writeconcern.Majority()).
+			SetReadConcern(readconcern.Snapshot()),
+	)
+
+	return errTransaction
+}
+
+// WithMajorityRead Method runs fn with reads seeing only majority committed data, ex. after a failover where
+// writes acknowledged by a former primary could be rolled back.
+// Reads take part when called with the context passed to fn. As the driver applies session read concerns only
+// within transactions fn runs in a transaction, so it could be run more than once and its writes, if any,
+// are committed with majority write concern when it returns nil. Requires a replica set or a sharded cluster.
+func (m *Client) WithMajorityRead(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, errSession := m.driver().StartSession()
+	if errSession != nil {
+		return errSession
+	}
+	defer session.EndSession(ctx)
+
+	_, errTransaction := session.WithTransaction(
+		ctx,
+		func(sessCtx mongo.SessionContext) (any, error) {
+			return nil, fn(sessCtx)
+		},
+		options.Transaction().
+			SetWriteConcern(writeconcern.Majority()).
+			SetReadConcern(readconcern.Majority()),
+	)
+
+	return errTransaction
+}
@@ -0,0 +1,133 @@
+package mongoclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"mongoclient/testutil"
+)
+
+// TestInsertThenRead Should read back the inserted record within the same session.
+func TestInsertThenRead(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t, mongodb.WithReplicaSet("rs0"))
+	defer cleanup()
+
+	ctx := context.Background()
+
+	record, errInsert := m.InsertThenRead(ctx, []byte(`{"Name":"mary","Age":44}`))
+	require.NoError(t, errInsert)
+	assert.Equal(t, "mary", record["Name"])
+	assert.NotNil(t, record["_id"])
+
+	_, errInvalid := m.InsertThenRead(ctx, []byte(`{"Name":`))
+	require.Error(t, errInvalid)
+}
+
+// TestSnapshotRead Should not see records written after the snapshot was taken.
+func TestSnapshotRead(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t, mongodb.WithReplicaSet("rs0"))
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	require.NoError(t,
+		m.SnapshotRead(ctx, func(ctxSnapshot context.Context) error {
+			before, errBefore := m.FindManyFilterJSON(ctxSnapshot, []byte(`{}`))
+			if errBefore != nil {
+				return errBefore
+			}
+
+			testInsertOne(ctx, t, m, mary)
+
+			after, errAfter := m.FindManyFilterJSON(ctxSnapshot, []byte(`{}`))
+			if errAfter != nil {
+				return errAfter
+			}
+
+			assert.Len(t, before, 1)
+			assert.Len(t, after, 1)
+
+			return nil
+		}),
+	)
+}
+
+// TestWithTransaction Should keep the writes of committed transactions only.
+func TestWithTransaction(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t, mongodb.WithReplicaSet("rs0"))
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// collection must exist as it cannot be created in a transaction on older servers.
+	testInsertOne(ctx, t, m, john)
+
+	require.NoError(t,
+		m.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+			if _, errInsert := m.InsertOne(sessCtx, []byte(`{"Name":"mary"}`)); errInsert != nil {
+				return errInsert
+			}
+
+			_, errInsert := m.InsertOne(sessCtx, []byte(`{"Name":"eve"}`))
+
+			return errInsert
+		}),
+	)
+
+	errAbort := errors.New("abort")
+
+	require.ErrorIs(t,
+		m.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+			if _, errInsert := m.InsertOne(sessCtx, []byte(`{"Name":"ghost"}`)); errInsert != nil {
+				return errInsert
+			}
+
+			return errAbort
+		}),
+		errAbort,
+	)
+
+	count, errCount := m.Count(ctx, nil)
+	require.NoError(t, errCount)
+	assert.EqualValues(t, 3, count)
+}
+
+// TestWithMajorityRead Should read majority committed records with the passed context.
+func TestWithMajorityRead(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t, mongodb.WithReplicaSet("rs0"))
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	require.NoError(t,
+		m.WithMajorityRead(ctx, func(ctxMajority context.Context) error {
+			records, errFind := m.FindManyFilterJSON(ctxMajority, []byte(`{}`))
+			if errFind != nil {
+				return errFind
+			}
+
+			assert.Len(t, records, 1)
+
+			return nil
+		}),
+	)
+
+	errRead := errors.New("read")
+
+	require.ErrorIs(t,
+		m.WithMajorityRead(ctx, func(context.Context) error {
+			return errRead
+		}),
+		errRead,
+	)
+}
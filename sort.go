@@ -0,0 +1,66 @@
+package mongoclient
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ParseSort Helper converts a sort specification as received in query parameters, ex. "age:-1,name:1",
+// to a sort document keeping the order of the fields.
+// Direction is 1 for ascending or -1 for descending and defaults to ascending when omitted.
+// An empty specification returns an empty sort document.
+func ParseSort(spec string) (bson.D, error) {
+	var result bson.D
+
+	if strings.TrimSpace(spec) == "" {
+		return result,
+			nil
+	}
+
+	for _, item := range strings.Split(spec, ",") {
+		field, direction, hasDirection := strings.Cut(strings.TrimSpace(item), ":")
+
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return nil,
+				errors.Errorf("sort item %q has no field", item)
+		}
+
+		order := 1
+
+		if hasDirection {
+			parsed, errParse := strconv.Atoi(strings.TrimSpace(direction))
+			if errParse != nil || (parsed != 1 && parsed != -1) {
+				return nil,
+					errors.Errorf("sort item %q should have direction 1 or -1", item)
+			}
+
+			order = parsed
+		}
+
+		result = append(result, bson.E{Key: field, Value: order})
+	}
+
+	return result,
+		nil
+}
+
+// FindManySorted Method finds data based on passed filter and returns it sorted as per passed specification.
+// See ParseSort for the format of the specification, for a sort document use WithSort with any find method.
+func (m *Client) FindManySorted(ctx context.Context, filter []byte, sortSpec string, opts ...ReadOption) ([]bson.M, error) {
+	sort, errSort := ParseSort(sortSpec)
+	if errSort != nil {
+		return nil,
+			errSort
+	}
+
+	return m.FindManyFilterJSON(
+		ctx,
+		filter,
+		append(opts, WithSort(sort))...,
+	)
+}
@@ -0,0 +1,38 @@
+package mongoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    bson.D
+		wantErr bool
+	}{
+		{spec: "", want: nil},
+		{spec: "age:-1,name:1", want: bson.D{{Key: "age", Value: -1}, {Key: "name", Value: 1}}},
+		{spec: " age : -1 , name ", want: bson.D{{Key: "age", Value: -1}, {Key: "name", Value: 1}}},
+		{spec: "age:2", wantErr: true},
+		{spec: "age:down", wantErr: true},
+		{spec: "age:1,,name:1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			sort, errParse := ParseSort(tt.spec)
+			if tt.wantErr {
+				require.Error(t, errParse)
+
+				return
+			}
+
+			require.NoError(t, errParse)
+			assert.Equal(t, tt.want, sort)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Package testutil provides a hermetic Mongo DB for tests.
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+
+	mongoclient "mongoclient"
+)
+
+// Image is the Mongo DB image the ephemeral containers are started from.
+var Image = "mongo:6"
+
+// StartMongo Helper spins up an ephemeral Mongo DB container and returns a connected client for it.
+// Returned function closes the client and removes the container.
+// Container could be customized with passed options, ex. mongodb.WithReplicaSet for session based features.
+// Test is skipped when no container provider, ex. Docker, is available.
+func StartMongo(t *testing.T, opts ...testcontainers.ContainerCustomizer) (*mongoclient.Client, func()) {
+	t.Helper()
+
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+
+	container, errRun := mongodb.Run(ctx, Image, opts...)
+	if errRun != nil {
+		testcontainers.CleanupContainer(t, container)
+
+		t.Fatalf("could not start Mongo DB container: %s", errRun)
+	}
+
+	url, errURL := container.ConnectionString(ctx)
+	if errURL != nil {
+		testcontainers.CleanupContainer(t, container)
+
+		t.Fatalf("could not get Mongo DB connection string: %s", errURL)
+	}
+
+	client, errNew := mongoclient.NewMongo(
+		&mongoclient.Cfg{
+			SecondsTimeoutExecution: 3,
+			URL:                     url,
+			Database:                "testing",
+			Collection:              "persons",
+		},
+	)
+	if errNew != nil {
+		testcontainers.CleanupContainer(t, container)
+
+		t.Fatalf("could not create client: %s", errNew)
+	}
+
+	return client,
+		func() {
+			_ = client.Close()
+
+			if errTerminate := testcontainers.TerminateContainer(container); errTerminate != nil {
+				t.Logf("could not terminate Mongo DB container: %s", errTerminate)
+			}
+		}
+}
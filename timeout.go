@@ -0,0 +1,56 @@
+package mongoclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// operationKey marks the contexts of running operations, so that operations run with them are not counted again.
+// FindOneResilient attempts are run with the caller context, each taking and releasing its own slot.
+type operationKey struct{}
+
+// withTimeout returns the context bounding a single operation, see withDeadline.
+// The operation is counted as in flight until the returned cancel is called, with MaxConcurrentOps it first
+// waits for a free slot. When passed context ends while waiting the returned context is done,
+// failing the operation with the context error.
+func (m *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctxLocal, cancel := m.withDeadline(ctx)
+
+	if m.conn == nil || ctx.Value(operationKey{}) != nil {
+		return ctxLocal, cancel
+	}
+
+	if m.conn.slots != nil {
+		select {
+		case m.conn.slots <- struct{}{}:
+
+		case <-ctxLocal.Done():
+			return ctxLocal, cancel
+		}
+	}
+
+	m.conn.inFlight.Add(1)
+
+	var release sync.Once
+
+	return context.WithValue(ctxLocal, operationKey{}, true),
+		func() {
+			cancel()
+
+			release.Do(func() {
+				m.conn.inFlight.Add(-1)
+
+				if m.conn.slots != nil {
+					<-m.conn.slots
+				}
+			})
+		}
+}
+
+// withDeadline returns the context bounding a single call to the server, not counted as an operation,
+// ex. for closing cursors.
+// The shorter of a deadline already set on passed context and the configured execution timeout applies.
+func (m *Client) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Duration(m.SecondsTimeoutExecution)*time.Second)
+}
@@ -0,0 +1,85 @@
+package mongoclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeout(t *testing.T) {
+	m := &Client{
+		Cfg: &Cfg{
+			SecondsTimeoutExecution: 3,
+		},
+	}
+
+	ctxConfig, cancelConfig := m.withTimeout(context.Background())
+	defer cancelConfig()
+
+	deadlineConfig, hasDeadline := ctxConfig.Deadline()
+	require.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(3*time.Second), deadlineConfig, time.Second)
+
+	ctxShort, cancelShort := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancelShort()
+
+	ctxShortLocal, cancelShortLocal := m.withTimeout(ctxShort)
+	defer cancelShortLocal()
+
+	deadlineShort, _ := ctxShort.Deadline()
+	deadlineShortLocal, _ := ctxShortLocal.Deadline()
+	assert.Equal(t, deadlineShort, deadlineShortLocal)
+
+	ctxLong, cancelLong := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelLong()
+
+	ctxLongLocal, cancelLongLocal := m.withTimeout(ctxLong)
+	defer cancelLongLocal()
+
+	deadlineLongLocal, _ := ctxLongLocal.Deadline()
+	assert.WithinDuration(t, time.Now().Add(3*time.Second), deadlineLongLocal, time.Second)
+}
+
+func TestWithTimeoutMaxConcurrentOps(t *testing.T) {
+	config := &Cfg{
+		URL:                     "mongodb://localhost:27017",
+		SecondsTimeoutExecution: 3,
+		MaxConcurrentOps:        1,
+	}
+
+	m := &Client{
+		Cfg:  config,
+		conn: newConnection(config),
+	}
+
+	ctxFirst, cancelFirst := m.withTimeout(context.Background())
+	require.NoError(t, ctxFirst.Err())
+	assert.EqualValues(t, 1, m.InFlight())
+
+	// Operations run with the context of a running one do not need another slot.
+	ctxNested, cancelNested := m.withTimeout(ctxFirst)
+	require.NoError(t, ctxNested.Err())
+	cancelNested()
+	assert.EqualValues(t, 1, m.InFlight())
+
+	ctxShort, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+
+	ctxWaiting, cancelWaiting := m.withTimeout(ctxShort)
+	require.ErrorIs(t, ctxWaiting.Err(), context.DeadlineExceeded)
+	cancelWaiting()
+	assert.EqualValues(t, 1, m.InFlight())
+
+	cancelFirst()
+	cancelFirst()
+	assert.Zero(t, m.InFlight())
+
+	ctxNext, cancelNext := m.withTimeout(context.Background())
+	require.NoError(t, ctxNext.Err())
+	assert.EqualValues(t, 1, m.InFlight())
+	cancelNext()
+	assert.Zero(t, m.InFlight())
+}
@@ -0,0 +1,252 @@
+package mongoclient
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Typed helpers are functions taking the client as Go methods cannot have type parameters.
+
+// FindOneAs Helper finds one record based on passed filter and decodes it into T.
+// When nothing matches the zero value and ErrNotFound are returned.
+func FindOneAs[T any](ctx context.Context, m *Client, filter []byte, opts ...ReadOption) (T, error) {
+	defer m.logSlow("FindOneAs", time.Now())
+
+	var result T
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonFilter, errConv := m.filterFromJSON(filter)
+	if errConv != nil {
+		return result,
+			errConv
+	}
+
+	collection, errCollection := m.readCollection(opts...)
+	if errCollection != nil {
+		return result,
+			errCollection
+	}
+
+	if errFind := collection.
+		FindOne(ctxLocal, bsonFilter, newFindOneOptions(opts...)).
+		Decode(&result); errFind != nil {
+		var zero T
+
+		return zero,
+			notFound(errFind)
+	}
+
+	return result,
+		nil
+}
+
+// FindManyAs Helper finds data based on passed filter and decodes the records into T.
+func FindManyAs[T any](ctx context.Context, m *Client, filter []byte, opts ...ReadOption) ([]T, error) {
+	defer m.logSlow("FindManyAs", time.Now())
+
+	return findManyTyped[T](ctx, m, filter, nil, opts...)
+}
+
+// FindManyTypedProjected Helper finds data based on passed filter and decodes only the projected fields into T.
+// An empty projection decodes whole records.
+func FindManyTypedProjected[T any](ctx context.Context, m *Client, filter []byte, projection bson.D, opts ...ReadOption) ([]T, error) {
+	defer m.logSlow("FindManyTypedProjected", time.Now())
+
+	return findManyTyped[T](ctx, m, filter, projection, opts...)
+}
+
+// findManyTyped finds the records matching passed filter and decodes them, or their projected fields, into T.
+func findManyTyped[T any](ctx context.Context, m *Client, filter []byte, projection bson.D, opts ...ReadOption) ([]T, error) {
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonFilter, errConv := m.filterFromJSON(filter)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	collection, errCollection := m.readCollection(opts...)
+	if errCollection != nil {
+		return nil,
+			errCollection
+	}
+
+	findOptions := newFindOptions(opts...)
+	if len(projection) > 0 {
+		findOptions.SetProjection(projection)
+	}
+
+	cursor, errFind := collection.Find(ctxLocal, bsonFilter, findOptions)
+	if errFind != nil {
+		return nil,
+			errFind
+	}
+	defer cursor.Close(ctxLocal)
+
+	var result []T
+
+	if m.EmptyResultNonNil {
+		result = []T{}
+	}
+
+	if errDecode := cursor.All(ctxLocal, &result); errDecode != nil {
+		return nil,
+			errors.Wrap(errDecode, "could not decode records")
+	}
+
+	return result,
+		nil
+}
+
+// UpsertReturning Helper applies passed update to the record matching passed filter, inserting it when none
+// matches, and returns the record as it is after the update decoded into T.
+// Covers the ensure exists and return current state pattern in one atomic round trip.
+func UpsertReturning[T any](ctx context.Context, m *Client, filter primitive.M, update bson.M) (*T, error) {
+	defer m.logSlow("UpsertReturning", time.Now())
+
+	m.logFilter(filter)
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	var result T
+
+	if errUpsert := m.writeCollection().
+		FindOneAndUpdate(
+			ctxLocal,
+			filter,
+			update,
+			options.FindOneAndUpdate().
+				SetUpsert(true).
+				SetReturnDocument(options.After),
+		).
+		Decode(&result); errUpsert != nil {
+		return nil,
+			errors.Wrap(writeConcernTimeout(errUpsert), "could not upsert record")
+	}
+
+	return &result,
+		nil
+}
+
+// ToStruct Helper converts passed record, ex. as returned by the find methods, to T using the BSON codec.
+func ToStruct[T any](m bson.M) (T, error) {
+	var result T
+
+	raw, errMarshal := bson.Marshal(m)
+	if errMarshal != nil {
+		return result,
+			errors.Wrap(errMarshal, "could not marshal record")
+	}
+
+	if errUnmarshal := bson.Unmarshal(raw, &result); errUnmarshal != nil {
+		return result,
+			errors.Wrap(errUnmarshal, "could not unmarshal record")
+	}
+
+	return result,
+		nil
+}
+
+// ToBSON Helper converts passed value to a record using the BSON codec.
+// Unlike the JSON path BSON types, ex. dates, object IDs or integers, are preserved.
+func ToBSON[T any](v T) (bson.M, error) {
+	raw, errMarshal := bson.Marshal(v)
+	if errMarshal != nil {
+		return nil,
+			errors.Wrap(errMarshal, "could not marshal value")
+	}
+
+	var result bson.M
+
+	if errUnmarshal := bson.Unmarshal(raw, &result); errUnmarshal != nil {
+		return nil,
+			errors.Wrap(errUnmarshal, "could not unmarshal value")
+	}
+
+	return result,
+		nil
+}
+
+// DiffUpdate Helper returns the update document turning oldValue into newValue, with a $set for the changed
+// or added fields and an $unset for the removed ones, ex. fields tagged omitempty that became empty.
+// Fields are compared at the top level, a changed embedded document is set whole.
+// When nothing changed the returned update is empty and the write can be skipped.
+func DiffUpdate[T any](oldValue, newValue T) (bson.M, error) {
+	oldRecord, errOld := ToBSON(oldValue)
+	if errOld != nil {
+		return nil,
+			errOld
+	}
+
+	newRecord, errNew := ToBSON(newValue)
+	if errNew != nil {
+		return nil,
+			errNew
+	}
+
+	set := bson.M{}
+
+	for field, value := range newRecord {
+		previous, existed := oldRecord[field]
+		if existed {
+			same, errCompare := sameValue(previous, value)
+			if errCompare != nil {
+				return nil,
+					errCompare
+			}
+
+			if same {
+				continue
+			}
+		}
+
+		set[field] = value
+	}
+
+	unset := bson.M{}
+
+	for field := range oldRecord {
+		if _, exists := newRecord[field]; !exists {
+			unset[field] = ""
+		}
+	}
+
+	result := bson.M{}
+
+	if len(set) > 0 {
+		result["$set"] = set
+	}
+
+	if len(unset) > 0 {
+		result["$unset"] = unset
+	}
+
+	return result,
+		nil
+}
+
+// sameValue compares passed values by their canonical BSON.
+func sameValue(a, b any) (bool, error) {
+	canonicalA, errA := canonicalBSON(a)
+	if errA != nil {
+		return false, errA
+	}
+
+	canonicalB, errB := canonicalBSON(b)
+	if errB != nil {
+		return false, errB
+	}
+
+	return bytes.Equal(canonicalA, canonicalB),
+		nil
+}
@@ -0,0 +1,153 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	mongoclient "mongoclient"
+	"mongoclient/testutil"
+)
+
+func TestFindAs(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+
+	person, errOne := mongoclient.FindOneAs[record](ctx, m, []byte(`{"Name":"john"}`))
+	require.NoError(t, errOne)
+	assert.Equal(t, john, person)
+
+	missing, errMissing := mongoclient.FindOneAs[record](ctx, m, []byte(`{"Name":"nobody"}`))
+	require.ErrorIs(t, errMissing, mongoclient.ErrNotFound)
+	assert.Zero(t, missing)
+
+	persons, errMany := mongoclient.FindManyAs[record](ctx, m, []byte(`{}`))
+	require.NoError(t, errMany)
+	assert.ElementsMatch(t, []record{john, mary}, persons)
+}
+
+func TestFindManyTypedProjected(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+	testInsertOne(ctx, t, m, mary)
+
+	type name struct {
+		Name string `bson:"Name"`
+		Age  uint   `bson:"Age"`
+	}
+
+	names, errFind := mongoclient.FindManyTypedProjected[name](
+		ctx,
+		m,
+		[]byte(`{}`),
+		bson.D{{Key: "Name", Value: 1}},
+	)
+	require.NoError(t, errFind)
+	require.Len(t, names, 2)
+	assert.ElementsMatch(t, []name{{Name: "john"}, {Name: "mary"}}, names)
+}
+
+func TestUpsertReturning(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	type counter struct {
+		Name  string `bson:"Name"`
+		Count int    `bson:"Count"`
+	}
+
+	filter := bson.M{"Name": "visits"}
+	increment := bson.M{"$inc": bson.M{"Count": 1}}
+
+	created, errCreate := mongoclient.UpsertReturning[counter](ctx, m, filter, increment)
+	require.NoError(t, errCreate)
+	assert.Equal(t, counter{Name: "visits", Count: 1}, *created)
+
+	updated, errUpdate := mongoclient.UpsertReturning[counter](ctx, m, filter, increment)
+	require.NoError(t, errUpdate)
+	assert.Equal(t, counter{Name: "visits", Count: 2}, *updated)
+}
+
+func TestToStructToBSON(t *testing.T) {
+	type person struct {
+		ID      primitive.ObjectID `bson:"_id"`
+		Name    string             `bson:"name"`
+		Age     int64              `bson:"age"`
+		Created time.Time          `bson:"created"`
+	}
+
+	value := person{
+		ID:      primitive.NewObjectID(),
+		Name:    "mary",
+		Age:     44,
+		Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	record, errToBSON := mongoclient.ToBSON(value)
+	require.NoError(t, errToBSON)
+	assert.Equal(t, value.ID, record["_id"])
+	assert.Equal(t, int64(44), record["age"])
+	assert.IsType(t, primitive.DateTime(0), record["created"])
+
+	converted, errToStruct := mongoclient.ToStruct[person](record)
+	require.NoError(t, errToStruct)
+	assert.Equal(t, value, converted)
+}
+
+func TestDiffUpdate(t *testing.T) {
+	type address struct {
+		City string `bson:"city"`
+	}
+
+	type person struct {
+		Name    string  `bson:"name"`
+		Age     int     `bson:"age"`
+		Email   string  `bson:"email,omitempty"`
+		Address address `bson:"address"`
+	}
+
+	old := person{
+		Name:    "john",
+		Age:     44,
+		Email:   "john@example.com",
+		Address: address{City: "Cluj"},
+	}
+
+	same, errSame := mongoclient.DiffUpdate(old, old)
+	require.NoError(t, errSame)
+	assert.Empty(t, same)
+
+	changed := old
+	changed.Age = 45
+	changed.Email = ""
+	changed.Address.City = "Iasi"
+
+	update, errDiff := mongoclient.DiffUpdate(old, changed)
+	require.NoError(t, errDiff)
+	assert.Equal(t,
+		bson.M{
+			"$set": bson.M{
+				"age":     int32(45),
+				"address": bson.M{"city": "Iasi"},
+			},
+			"$unset": bson.M{"email": ""},
+		},
+		update,
+	)
+}
@@ -0,0 +1,86 @@
+package mongoclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ValidateDocuments Method returns the IDs of the records not satisfying passed JSON schema.
+// Schema is the value of a $jsonSchema operator, ex. {"required":["Name"]}.
+// Could be used to audit existing data before enabling a strict collection validator.
+// Errors when a failing record has an _id that is not an object ID, ex. from an IDGenerator.
+func (m *Client) ValidateDocuments(ctx context.Context, schema []byte) ([]primitive.ObjectID, error) {
+	defer m.logSlow("ValidateDocuments", time.Now())
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	bsonSchema, errConv := jsonToBsonM(schema)
+	if errConv != nil {
+		return nil,
+			errConv
+	}
+
+	cursor, errFind := m.driver().
+		Database(m.Database).
+		Collection(m.Collection).
+		Find(
+			ctxLocal,
+			bson.M{
+				"$nor": bson.A{
+					bson.M{"$jsonSchema": bsonSchema},
+				},
+			},
+			options.Find().SetProjection(bson.M{"_id": 1}),
+		)
+	if errFind != nil {
+		return nil,
+			errFind
+	}
+	defer cursor.Close(ctxLocal)
+
+	records, errWalk := m.walkMongoSet(ctxLocal, cursor)
+	if errWalk != nil {
+		return nil,
+			errWalk
+	}
+
+	return objectIDsStrict(records)
+}
+
+// ValidateFilter Method checks passed JSON filter parses, uses no denied operator and is a legal query,
+// ex. before saving a user defined filter. The server plans the query without running it.
+func (m *Client) ValidateFilter(ctx context.Context, filterJSON []byte) error {
+	defer m.logSlow("ValidateFilter", time.Now())
+
+	bsonFilter, errConv := m.filterFromJSON(filterJSON)
+	if errConv != nil {
+		return errors.Wrap(errConv, "invalid filter")
+	}
+
+	ctxLocal, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	if errExplain := m.driver().
+		Database(m.Database).
+		RunCommand(
+			ctxLocal,
+			bson.D{
+				{Key: "explain", Value: bson.D{
+					{Key: "find", Value: m.Collection},
+					{Key: "filter", Value: bsonFilter},
+				}},
+				{Key: "verbosity", Value: "queryPlanner"},
+			},
+		).
+		Err(); errExplain != nil {
+		return errors.Wrap(errExplain, "invalid filter")
+	}
+
+	return nil
+}
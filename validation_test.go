@@ -0,0 +1,47 @@
+package mongoclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"mongoclient/testutil"
+)
+
+// TestValidateDocuments Should return only the records failing the schema.
+func TestValidateDocuments(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	testInsertOne(ctx, t, m, john)
+
+	idInvalid, errInsert := m.InsertOne(ctx, []byte(`{"Gender":"female"}`))
+	require.NoError(t, errInsert)
+
+	invalid, errValidate := m.ValidateDocuments(ctx, []byte(`{"required":["Name"]}`))
+	require.NoError(t, errValidate)
+	assert.Equal(t, []primitive.ObjectID{idInvalid}, invalid)
+}
+
+// TestValidateFilter Should accept legal queries and reject malformed ones with a descriptive error.
+func TestValidateFilter(t *testing.T) {
+	m, cleanup := testutil.StartMongo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, m.ValidateFilter(ctx, []byte(`{"Age":{"$gt":40}}`)))
+
+	errJSON := m.ValidateFilter(ctx, []byte(`{"Age":`))
+	require.Error(t, errJSON)
+	assert.Contains(t, errJSON.Error(), "invalid filter")
+
+	errOperator := m.ValidateFilter(ctx, []byte(`{"Age":{"$bogus":40}}`))
+	require.Error(t, errOperator)
+	assert.Contains(t, errOperator.Error(), "$bogus")
+}
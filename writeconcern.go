@@ -0,0 +1,89 @@
+package mongoclient
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// WriteConcern is the acknowledgment requested from the server for writes.
+type WriteConcern struct {
+	// W is the number of members that should acknowledge the write, ex. 1, or "majority" or a tag set name.
+	// When nil the server default applies.
+	W any
+
+	// Journal requests the write to be acknowledged only once written to the on-disk journal.
+	Journal bool
+
+	// WTimeout bounds the wait for the acknowledgment, after which writes fail with ErrWriteConcernTimeout.
+	// Zero waits without limit.
+	WTimeout time.Duration
+}
+
+func (c *WriteConcern) validate() error {
+	switch typed := c.W.(type) {
+	case nil, string:
+	case int:
+		if typed < 0 {
+			return errors.Errorf("w %d should not be negative", typed)
+		}
+
+	default:
+		return errors.Errorf("w %v should be a number of members or a string", c.W)
+	}
+
+	if c.WTimeout < 0 {
+		return errors.Errorf("wtimeout %s should not be negative", c.WTimeout)
+	}
+
+	return nil
+}
+
+func (c *WriteConcern) toDriver() *writeconcern.WriteConcern {
+	result := writeconcern.WriteConcern{
+		W:        c.W,
+		WTimeout: c.WTimeout,
+	}
+
+	if c.Journal {
+		journal := true
+		result.Journal = &journal
+	}
+
+	return &result
+}
+
+// UseWriteConcern Method returns a client sharing the connection whose writes use passed write concern,
+// ex. m.UseWriteConcern(&WriteConcern{W: "majority", Journal: true}).InsertOne(ctx, data).
+func (m *Client) UseWriteConcern(concern *WriteConcern) *Client {
+	config := m.Cfg.ForCollection(m.Collection)
+	config.WriteConcern = concern
+
+	return &Client{
+		Cfg:  config,
+		conn: m.conn,
+	}
+}
+
+// writeCollection returns configured collection with the configured write concern, if any.
+func (m *Client) writeCollection() *mongo.Collection {
+	return m.writeCollectionNamed(m.Collection)
+}
+
+// writeCollectionNamed returns passed collection of configured database with the configured write concern, if any,
+// ex. for the sequence counters.
+func (m *Client) writeCollectionNamed(name string) *mongo.Collection {
+	if m.WriteConcern == nil {
+		return m.driver().Database(m.Database).Collection(name)
+	}
+
+	return m.driver().
+		Database(m.Database).
+		Collection(
+			name,
+			options.Collection().SetWriteConcern(m.WriteConcern.toDriver()),
+		)
+}
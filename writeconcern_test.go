@@ -0,0 +1,44 @@
+package mongoclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteConcernToDriver(t *testing.T) {
+	concern := WriteConcern{
+		W:        "majority",
+		Journal:  true,
+		WTimeout: 5 * time.Second,
+	}
+
+	converted := concern.toDriver()
+	assert.Equal(t, "majority", converted.W)
+	require.NotNil(t, converted.Journal)
+	assert.True(t, *converted.Journal)
+	assert.Equal(t, 5*time.Second, converted.WTimeout)
+
+	assert.Nil(t, (&WriteConcern{W: 1}).toDriver().Journal)
+}
+
+func TestUseWriteConcern(t *testing.T) {
+	m := &Client{
+		Cfg: &Cfg{
+			Database:   "testing",
+			Collection: "persons",
+		},
+		conn: &connection{},
+	}
+
+	concern := &WriteConcern{W: "majority"}
+
+	majority := m.UseWriteConcern(concern)
+
+	assert.Same(t, concern, majority.WriteConcern)
+	assert.Nil(t, m.WriteConcern)
+	assert.Equal(t, "persons", majority.Collection)
+	assert.Same(t, m.conn, majority.conn)
+}